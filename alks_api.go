@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Cox-Automotive/alks-go"
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+)
+
+// alksHTTPClient sends the requests for ALKS endpoints that alks-go doesn't
+// cover yet. Requests are still built and signed by the alks.Client.
+var alksHTTPClient = cleanhttp.DefaultClient()
+
+// alksDo sends a request to an ALKS endpoint and decodes a successful JSON
+// response into out, reporting failures the same way alks-go does.
+func alksDo(client *alks.Client, method string, path string, body []byte, out interface{}) *alks.AlksError {
+	req, err := client.NewRequest(body, method, path)
+	if err != nil {
+		return &alks.AlksError{Err: err}
+	}
+
+	resp, err := alksHTTPClient.Do(req)
+	if err != nil {
+		return &alks.AlksError{Err: err}
+	}
+	defer resp.Body.Close()
+
+	reqID := alks.GetRequestID(resp)
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &alks.AlksError{StatusCode: resp.StatusCode, RequestId: reqID, Err: err}
+	}
+
+	if err := checkJSONResponse(client.BaseURL, resp, data); err != nil {
+		return &alks.AlksError{StatusCode: resp.StatusCode, RequestId: reqID, Err: err}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respErr := new(alks.AlksResponseError)
+		if err := json.Unmarshal(data, respErr); err != nil {
+			return &alks.AlksError{StatusCode: resp.StatusCode, RequestId: reqID, Err: fmt.Errorf(alks.ParseError, err)}
+		}
+
+		if len(respErr.Errors) > 0 {
+			return &alks.AlksError{
+				StatusCode: resp.StatusCode,
+				RequestId:  reqID,
+				Err:        fmt.Errorf(alks.AlksResponsErrorStrings, strings.Join(respErr.Errors, ", ")),
+			}
+		}
+
+		return &alks.AlksError{StatusCode: resp.StatusCode, RequestId: reqID, Err: fmt.Errorf(alks.GenericAlksError)}
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return &alks.AlksError{StatusCode: resp.StatusCode, RequestId: reqID, Err: fmt.Errorf("Error parsing ALKS response: %s", err)}
+	}
+
+	return nil
+}
+
+// roleType is an entry in the ALKS role type catalog, i.e. a valid type for
+// alks_iamrole and alks_iamtrustrole. alks-go has no model for the catalog, so
+// the fields follow the AwsRoleType that ALKS.js, ALKS's JavaScript client,
+// decodes from the same /allAwsRoleTypes response.
+type roleType struct {
+	RoleTypeName      string                 `json:"roleTypeName"`
+	TrustRelationship map[string]interface{} `json:"trustRelationship"`
+	DefaultArns       []string               `json:"defArns"`
+	InstanceProfile   bool                   `json:"insProfile"`
+	TemplateFields    []string               `json:"templateFields"`
+}
+
+type listRoleTypesResponse struct {
+	alks.BaseResponse
+	RoleTypes []roleType `json:"roleTypes"`
+}
+
+// listRoleTypes fetches the ALKS role type catalog.
+func listRoleTypes(client *alks.Client) (*listRoleTypesResponse, *alks.AlksError) {
+	resp := new(listRoleTypesResponse)
+	if err := alksDo(client, "GET", "/allAwsRoleTypes", nil, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.RequestFailed() {
+		return nil, &alks.AlksError{
+			RequestId: resp.RequestID,
+			Err:       fmt.Errorf("Error listing role types: %s", strings.Join(resp.GetErrors(), ", ")),
+		}
+	}
+
+	return resp, nil
+}
+
+// trustPrincipals returns every principal trusted by a trust policy, such as
+// "ec2.amazonaws.com", in the order they appear.
+func trustPrincipals(policy map[string]interface{}) []string {
+	principals := []string{}
+
+	statements, _ := policy["Statement"].([]interface{})
+	for _, s := range statements {
+		statement, _ := s.(map[string]interface{})
+		principal, _ := statement["Principal"].(map[string]interface{})
+
+		for _, key := range []string{"Service", "AWS", "Federated"} {
+			switch v := principal[key].(type) {
+			case string:
+				principals = append(principals, v)
+			case []interface{}:
+				for _, p := range v {
+					if p, ok := p.(string); ok {
+						principals = append(principals, p)
+					}
+				}
+			}
+		}
+	}
+
+	return principals
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+// alksAPIPath is the path the ALKS API is usually served under.
+const alksAPIPath = "/rest"
+
+// normalizeAlksURL checks url is an absolute http(s) URL and strips trailing
+// slashes, since alks-go appends endpoint paths to it as they are.
+func normalizeAlksURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("url %q is not a valid ALKS URL: %s", raw, err)
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", fmt.Errorf("url %q is not a valid ALKS URL: it must be an absolute http or https URL, such as https://alks.example.com%s", raw, alksAPIPath)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("url %q is not a valid ALKS URL: it must not have a query or fragment", raw)
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+
+	return u.String(), nil
+}
+
+// validateAlksURL is a ValidateFunc for the provider's url argument.
+func validateAlksURL(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := normalizeAlksURL(v.(string)); err != nil {
+		errs = append(errs, err)
+	}
+
+	return
+}
+
+// unexpectedResponseError is returned when a URL answers with something other
+// than the JSON the ALKS API sends, which usually means it isn't the API's base URL.
+type unexpectedResponseError struct {
+	baseURL     string
+	status      string
+	contentType string
+}
+
+func (e *unexpectedResponseError) Error() string {
+	msg := fmt.Sprintf("ALKS at %s responded %s with %s instead of JSON, so url is probably not the base URL of the ALKS API.", e.baseURL, e.status, e.contentType)
+	if suggestion := suggestAlksURL(e.baseURL); suggestion != "" {
+		msg += fmt.Sprintf(" Did you mean %q?", suggestion)
+	}
+
+	return msg
+}
+
+// checkJSONResponse returns an unexpectedResponseError when data, the body of
+// resp, isn't JSON.
+func checkJSONResponse(baseURL string, resp *http.Response, data []byte) error {
+	contentType := resp.Header.Get("Content-Type")
+	trimmed := bytes.TrimSpace(data)
+
+	isHTML := strings.Contains(contentType, "text/html") || bytes.HasPrefix(trimmed, []byte("<"))
+	if !isHTML && (len(trimmed) == 0 || json.Valid(trimmed)) {
+		return nil
+	}
+
+	if contentType == "" {
+		contentType = "an unknown content type"
+	}
+
+	return &unexpectedResponseError{baseURL: baseURL, status: resp.Status, contentType: contentType}
+}
+
+// suggestAlksURL returns the base URL the user likely meant, or "" when there
+// is no better guess than baseURL.
+func suggestAlksURL(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil || strings.HasSuffix(u.Path, alksAPIPath) {
+		return ""
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/") + alksAPIPath
+
+	return u.String()
+}
+
+// checkAlksURL calls ALKS with client to tell whether its base URL is the
+// ALKS API. It only returns an error for responses that show it isn't, such
+// as an HTML page or a 404, and not for errors like expired credentials.
+func checkAlksURL(client *alks.Client) error {
+	var out alks.LoginRoleResponse
+	err := alksDo(client, "GET", "/loginRoles/id/me", nil, &out)
+	if err == nil {
+		return nil
+	}
+
+	var unexpected *unexpectedResponseError
+	if errors.As(err.Err, &unexpected) {
+		return unexpected
+	}
+
+	if err.StatusCode == http.StatusNotFound {
+		msg := fmt.Sprintf("ALKS at %s responded 404 Not Found, so url is probably not the base URL of the ALKS API.", client.BaseURL)
+		if suggestion := suggestAlksURL(client.BaseURL); suggestion != "" {
+			msg += fmt.Sprintf(" Did you mean %q?", suggestion)
+		}
+		return errors.New(msg)
+	}
+
+	return nil
+}
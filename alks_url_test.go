@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+func TestNormalizeAlksURL(t *testing.T) {
+	cases := []struct {
+		raw      string
+		expected string
+		valid    bool
+	}{
+		{"https://alks.example.com/rest", "https://alks.example.com/rest", true},
+		{"https://alks.example.com/rest/", "https://alks.example.com/rest", true},
+		{" https://alks.example.com/rest// ", "https://alks.example.com/rest", true},
+		{"http://localhost:8080", "http://localhost:8080", true},
+		{"alks.example.com/rest", "", false},
+		{"ftp://alks.example.com/rest", "", false},
+		{"https://alks.example.com/rest?foo=bar", "", false},
+		{"", "", false},
+	}
+
+	for _, c := range cases {
+		url, err := normalizeAlksURL(c.raw)
+		if (err == nil) != c.valid {
+			t.Errorf("%q: expected valid=%t, got %v", c.raw, c.valid, err)
+		}
+		if url != c.expected {
+			t.Errorf("%q: expected %q, got %q", c.raw, c.expected, url)
+		}
+	}
+}
+
+func TestSuggestAlksURL(t *testing.T) {
+	if s := suggestAlksURL("https://alks.example.com"); s != "https://alks.example.com/rest" {
+		t.Errorf("Expected /rest to be suggested, got %q", s)
+	}
+	if s := suggestAlksURL("https://alks.example.com/rest"); s != "" {
+		t.Errorf("Expected no suggestion, got %q", s)
+	}
+}
+
+func TestCheckAlksURL(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	fake.override("GET", "/api/loginRoles/id/me", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{}`)
+	})
+
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{"/rest", ""},
+		{"", `responded 404 Not Found with text/plain; charset=utf-8 instead of JSON, so url is probably not the base URL of the ALKS API. Did you mean "` + fake.URL + `/rest"?`},
+		{"/rest/api", `responded 404 Not Found, so url is probably not the base URL of the ALKS API. Did you mean "` + fake.URL + `/rest/api/rest"?`},
+	}
+
+	for _, c := range cases {
+		client, err := alks.NewSTSClient(fake.URL+c.path, "access", "secret", "token")
+		if err != nil {
+			t.Fatalf("Error creating client: %s", err)
+		}
+
+		err = checkAlksURL(client)
+		switch {
+		case c.expected == "" && err != nil:
+			t.Errorf("%q: unexpected error: %s", c.path, err)
+		case c.expected != "" && (err == nil || !strings.Contains(err.Error(), c.expected)):
+			t.Errorf("%q: expected an error containing %q, got %v", c.path, c.expected, err)
+		}
+	}
+
+	// expired credentials are for the provider to report, not a wrong url
+	fake.override("GET", "/loginRoles/id/me", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errors": ["expired token"]}`)
+	})
+	if err := checkAlksURL(fake.client(t)); err != nil {
+		t.Errorf("Expected expired credentials not to be reported as a wrong url, got %s", err)
+	}
+}
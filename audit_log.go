@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+const (
+	auditOutcomeSuccess = "success"
+	auditOutcomeFailure = "failure"
+)
+
+// auditLogger appends a JSON line to a local file for every mutating ALKS call
+// made by the provider. A nil *auditLogger is valid and records nothing.
+type auditLogger struct {
+	path   string
+	caller string
+	mu     sync.Mutex
+}
+
+// auditRecord is a single audit log entry. It must never hold credentials, so
+// only identifiers and the outcome of the call are recorded.
+type auditRecord struct {
+	Timestamp string `json:"timestamp"`
+	Caller    string `json:"caller"`
+	Account   string `json:"account"`
+	Role      string `json:"role"`
+	Operation string `json:"operation"`
+	Target    string `json:"target"`
+	Outcome   string `json:"outcome"`
+	Error     string `json:"error,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// newAuditLogger returns an audit logger writing to path on behalf of caller,
+// or nil when no path is configured. The file is opened once up front so an
+// unwritable path fails provider configuration rather than a later apply.
+func newAuditLogger(path string, caller string) (*auditLogger, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening audit log file %q: %s", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("Error closing audit log file %q: %s", path, err)
+	}
+
+	return &auditLogger{path: path, caller: caller}, nil
+}
+
+// record appends an entry for a mutating ALKS call against target. The ALKS
+// request ID is taken from the response or the error, whichever has one.
+func (a *auditLogger) record(client *alks.Client, operation string, target string, resp interface{}, err *alks.AlksError) {
+	if a == nil {
+		return
+	}
+
+	entry := auditRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Caller:    a.caller,
+		Account:   client.AccountDetails.Account,
+		Role:      client.AccountDetails.Role,
+		Operation: operation,
+		Target:    target,
+		Outcome:   auditOutcomeSuccess,
+		RequestID: responseRequestID(resp),
+	}
+
+	if err != nil {
+		entry.Outcome = auditOutcomeFailure
+		entry.Error = err.Error()
+		if err.RequestId != "" {
+			entry.RequestID = err.RequestId
+		}
+	}
+
+	if writeErr := a.write(entry); writeErr != nil {
+		log.Printf("[ERROR] Error writing audit log entry for %s on %s: %s", operation, target, writeErr)
+	}
+}
+
+func (a *auditLogger) write(entry auditRecord) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+func readAuditRecords(t *testing.T, path string) []auditRecord {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Error opening audit log: %s", err)
+	}
+	defer f.Close()
+
+	var records []auditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Error parsing audit log line %q: %s", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestNewAuditLogger_NoPath(t *testing.T) {
+	logger, err := newAuditLogger("", "arn:aws:sts::012345678910:assumed-role/Admin/foo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if logger != nil {
+		t.Fatal("Expected a nil audit logger when no path is configured")
+	}
+
+	// a nil logger must be safe to record against
+	logger.record(&alks.Client{}, "CreateIamRole", "foo", nil, nil)
+}
+
+func TestNewAuditLogger_UnwritablePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "audit.log")
+	if _, err := newAuditLogger(path, ""); err == nil {
+		t.Fatal("Expected an error for an audit log in a missing directory")
+	}
+}
+
+func TestAuditLogger_Record(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := newAuditLogger(path, "arn:aws:sts::012345678910:assumed-role/Admin/foo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	client := &alks.Client{AccountDetails: alks.AccountDetails{Account: "012345678910/ALKSAdmin - foo", Role: "Admin"}}
+
+	created := &alks.CreateIamUserResponse{
+		BaseResponse: alks.BaseResponse{RequestID: "req-1"},
+		CreateIamUserApiResponse: alks.CreateIamUserApiResponse{
+			IAMUserName: "bar",
+			AccessKey:   "AKIAEXAMPLE",
+			SecretKey:   "super-secret",
+		},
+	}
+	logger.record(client, "CreateIamUser", "bar", created, nil)
+	logger.record(client, "DeleteIamRole", "foo", nil, &alks.AlksError{
+		StatusCode: 404,
+		RequestId:  "req-2",
+		Err:        errors.New("Role not found"),
+	})
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error reading audit log: %s", err)
+	}
+	if strings.Contains(string(contents), "super-secret") || strings.Contains(string(contents), "AKIAEXAMPLE") {
+		t.Fatalf("Audit log must not contain credentials: %s", contents)
+	}
+
+	records := readAuditRecords(t, path)
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 audit records, got %d", len(records))
+	}
+
+	expected := []auditRecord{
+		{
+			Caller:    "arn:aws:sts::012345678910:assumed-role/Admin/foo",
+			Account:   "012345678910/ALKSAdmin - foo",
+			Role:      "Admin",
+			Operation: "CreateIamUser",
+			Target:    "bar",
+			Outcome:   auditOutcomeSuccess,
+			RequestID: "req-1",
+		},
+		{
+			Caller:    "arn:aws:sts::012345678910:assumed-role/Admin/foo",
+			Account:   "012345678910/ALKSAdmin - foo",
+			Role:      "Admin",
+			Operation: "DeleteIamRole",
+			Target:    "foo",
+			Outcome:   auditOutcomeFailure,
+			RequestID: "req-2",
+		},
+	}
+
+	for i, record := range records {
+		if record.Timestamp == "" {
+			t.Fatalf("Expected record %d to have a timestamp", i)
+		}
+		record.Timestamp = ""
+		record.Error = ""
+		if record != expected[i] {
+			t.Fatalf("Unexpected audit record %d: %#v vs %#v", i, record, expected[i])
+		}
+	}
+	if !strings.Contains(records[1].Error, "Role not found") {
+		t.Fatalf("Expected failure record to include the error, got %q", records[1].Error)
+	}
+}
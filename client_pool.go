@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clientPool hands out ALKS clients for accounts other than the provider's
+// own. Each account/role client is minted at most once, even when several
+// resources ask for it concurrently; a failed mint is retried on the next request.
+type clientPool struct {
+	mint func(ctx context.Context, account string, role string) (*alks.Client, *alks.AlksError)
+
+	mu      sync.Mutex
+	clients map[string]*pooledClient
+}
+
+type pooledClient struct {
+	once   sync.Once
+	client *alks.Client
+	err    *alks.AlksError
+}
+
+func newClientPool(mint func(ctx context.Context, account string, role string) (*alks.Client, *alks.AlksError)) *clientPool {
+	return &clientPool{
+		mint:    mint,
+		clients: make(map[string]*pooledClient),
+	}
+}
+
+// get returns the client for account and role, minting it if needed.
+func (p *clientPool) get(ctx context.Context, account string, role string) (*alks.Client, *alks.AlksError) {
+	key := account + "/" + role
+
+	p.mu.Lock()
+	entry, ok := p.clients[key]
+	if !ok {
+		entry = &pooledClient{}
+		p.clients[key] = entry
+	}
+	p.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.client, entry.err = p.mint(ctx, account, role)
+	})
+
+	if entry.err != nil {
+		p.mu.Lock()
+		if p.clients[key] == entry {
+			delete(p.clients, key)
+		}
+		p.mu.Unlock()
+
+		return nil, entry.err
+	}
+
+	return entry.client, nil
+}
+
+// AccountSchema and RoleSchema let a resource be managed in an account other
+// than the provider's. They must be set together.
+func AccountSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		RequiredWith: []string{"role"},
+		ValidateFunc: ValidAccountNumber,
+		Description:  "The 12 digit account number to manage this resource in, overriding the provider's account.",
+	}
+}
+
+func RoleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		RequiredWith: []string{"account"},
+		Description:  "The role to manage this resource with, overriding the provider's role.",
+	}
+}
+
+// importStateAccountQualified accepts either a plain ID or one qualified with
+// the account and role the resource lives in, as <account>/<role>/<id>.
+func importStateAccountQualified(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	switch len(parts) {
+	case 1:
+		return []*schema.ResourceData{d}, nil
+	case 3:
+		if !accountNumberRegexp.MatchString(parts[0]) || parts[1] == "" || parts[2] == "" {
+			break
+		}
+		_ = d.Set("account", parts[0])
+		_ = d.Set("role", parts[1])
+		d.SetId(parts[2])
+		return []*schema.ResourceData{d}, nil
+	}
+
+	return nil, fmt.Errorf("Unexpected import ID %q, expected <id> or <account>/<role>/<id> with a 12 digit account number", d.Id())
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestClientPool_MintsOncePerAccount(t *testing.T) {
+	var mints int32
+	pool := newClientPool(func(_ context.Context, account string, role string) (*alks.Client, *alks.AlksError) {
+		atomic.AddInt32(&mints, 1)
+		return &alks.Client{AccountDetails: alks.AccountDetails{Account: account + "/ALKS" + role, Role: role}}, nil
+	})
+
+	var wg sync.WaitGroup
+	clients := make([]*alks.Client, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = pool.get(context.Background(), "012345678910", "Admin")
+		}(i)
+	}
+	wg.Wait()
+
+	if mints != 1 {
+		t.Fatalf("Expected the client to be minted once, got %d", mints)
+	}
+	for _, c := range clients {
+		if c != clients[0] {
+			t.Fatal("Expected every caller to get the same client")
+		}
+	}
+
+	if other, _ := pool.get(context.Background(), "109876543210", "Admin"); other == clients[0] {
+		t.Fatal("Expected a separate client for another account")
+	}
+	if mints != 2 {
+		t.Fatalf("Expected a second mint for another account, got %d", mints)
+	}
+}
+
+func TestClientPool_RetriesFailedMint(t *testing.T) {
+	fail := true
+	pool := newClientPool(func(_ context.Context, account string, role string) (*alks.Client, *alks.AlksError) {
+		if fail {
+			return nil, &alks.AlksError{StatusCode: 403, Err: errors.New("forbidden")}
+		}
+		return &alks.Client{}, nil
+	})
+
+	if _, err := pool.get(context.Background(), "012345678910", "Admin"); err == nil {
+		t.Fatal("Expected the mint error")
+	}
+
+	fail = false
+	if c, err := pool.get(context.Background(), "012345678910", "Admin"); err != nil || c == nil {
+		t.Fatalf("Expected a client after retrying, got %v", err)
+	}
+}
+
+func TestResourceClient_AccountOverride(t *testing.T) {
+	accountClient := &alks.Client{}
+	providerStruct := &AlksClient{
+		client: &alks.Client{},
+		accountClients: newClientPool(func(_ context.Context, account string, role string) (*alks.Client, *alks.AlksError) {
+			if account != "012345678910" || role != "Admin" {
+				t.Fatalf("Unexpected account %q and role %q", account, role)
+			}
+			return accountClient, nil
+		}),
+	}
+
+	d := resourceAlksLtk().TestResourceData()
+	_ = d.Set("account", "012345678910")
+	_ = d.Set("role", "Admin")
+
+	if actual, err := providerStruct.resourceClient(context.Background(), d); err != nil || actual != accountClient {
+		t.Fatal("Expected the pooled client for the resource's account")
+	}
+}
+
+func TestImportStateAccountQualified(t *testing.T) {
+	cases := []struct {
+		id      string
+		account string
+		role    string
+		name    string
+		valid   bool
+	}{
+		{"my-role", "", "", "my-role", true},
+		{"012345678910/Admin/my-role", "012345678910", "Admin", "my-role", true},
+		{"012345678910/my-role", "", "", "", false},
+		{"012345678910//my-role", "", "", "", false},
+		{"123/Admin/my-role", "", "", "", false},
+	}
+
+	for _, c := range cases {
+		d := resourceAlksIamRole().TestResourceData()
+		d.SetId(c.id)
+
+		_, err := importStateAccountQualified(context.Background(), d, nil)
+		if !c.valid {
+			if err == nil {
+				t.Fatalf("Expected an error importing %q", c.id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error importing %q: %s", c.id, err)
+		}
+
+		if d.Id() != c.name || d.Get("account") != c.account || d.Get("role") != c.role {
+			t.Fatalf("Importing %q got id %q, account %q, role %q", c.id, d.Id(), d.Get("account"), d.Get("role"))
+		}
+	}
+}
+
+func TestNewAccountClient(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	client, err := alks.NewSTSClient(fake.alksURL(), fakeAlksAccessKey, "secret", "token")
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	same, alksErr := newAccountClient(context.Background(), fake.alksURL(), fakeAlksAccount, fakeAlksRole, client)
+	if alksErr != nil || same != client {
+		t.Fatalf("Expected the client itself for its own account and role, got %v", alksErr)
+	}
+
+	other, alksErr := newAccountClient(context.Background(), fake.alksURL(), fakeAlksAccount, fakeAlksRole+"Other", client)
+	if alksErr != nil || other == client || other.AccountDetails.Role != fakeAlksRole+"Other" {
+		t.Fatalf("Expected a client for the other role, got %v (%v)", other, alksErr)
+	}
+
+	unknown := *client
+	unknown.AccountDetails = alks.AccountDetails{}
+	if _, alksErr := newAccountClient(context.Background(), fake.alksURL(), fakeAlksAccount, fakeAlksRole, &unknown); alksErr == nil {
+		t.Fatal("Expected an error when the client's own account is unknown")
+	}
+}
+
+func TestAccountSchema_RejectsShortAccounts(t *testing.T) {
+	resources := map[string]*schema.Resource{
+		"alks_keys":    dataSourceAlksKeys(),
+		"alks_iamrole": resourceAlksIamRole(),
+		"alks_ltk":     resourceAlksLtk(),
+	}
+
+	for name, r := range resources {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{"account": "123", "role": "Dev", "name": "foo", "type": "Amazon EC2", "iam_username": "foo"})
+		diags := r.Validate(config)
+		if !diags.HasError() || !strings.Contains(fmt.Sprint(diags), "12 digit AWS account number") {
+			t.Errorf("%s: expected a short account to be rejected, got %v", name, diags)
+		}
+	}
+}
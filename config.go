@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -34,6 +35,23 @@ type Config struct {
 	AssumeRole    assumeRoleDetails
 	Account       string
 	Role          string
+	UserAgent     []string
+	STSEndpoint   string
+
+	// populated by Client() from STS once the credentials have been validated
+	baseIdentity *sts.GetCallerIdentityOutput
+
+	// populated by Client() from STS with the identity of the client it
+	// returns, i.e. after any switch to Account and Role
+	callerIdentity *sts.GetCallerIdentityOutput
+
+	// populated by Client() with the name of the AWS credential provider the
+	// base credentials came from, before any assume_role
+	credentialSource string
+
+	// populated by Client() with the ALKS client for the base credentials,
+	// before any switch to Account and Role
+	baseClient *alks.Client
 }
 
 type assumeRoleDetails struct {
@@ -63,7 +81,7 @@ func getCredentials(c *Config) *credentials.Credentials {
 	return credentials.NewChainCredentials(providers)
 }
 
-func getCredentialsFromSession(c *Config) (*credentials.Credentials, error) {
+func getCredentialsFromSession(ctx context.Context, c *Config) (*credentials.Credentials, error) {
 	var sess *session.Session
 	var err error
 	options := &session.Options{
@@ -82,6 +100,7 @@ func getCredentialsFromSession(c *Config) (*credentials.Credentials, error) {
 		}
 		return nil, fmt.Errorf("Error creating AWS session: %s", err)
 	}
+	traceAWSRequests(ctx, &sess.Handlers)
 	creds := sess.Config.Credentials
 	cp, err := sess.Config.Credentials.Get()
 	if err != nil {
@@ -94,7 +113,62 @@ func getCredentialsFromSession(c *Config) (*credentials.Credentials, error) {
 }
 
 // Client returns a properly configured ALKS client or an appropriate error if initialization fails
-func (c *Config) Client() (*alks.Client, error) {
+func (c *Config) Client(ctx context.Context) (*alks.Client, error) {
+	creds, err := c.baseCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stsconn, cp, err := c.stsConnection(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	// make a basic api call to test creds are valid
+	if err := c.verifyCredentials(stsconn); err != nil {
+		return nil, err
+	}
+
+	// got good creds, create alks sts client
+	client, err := newTracedSTSClient(ctx, c.URL, cp.AccessKeyID, cp.SecretAccessKey, cp.SessionToken)
+	if err != nil {
+		return nil, err
+	}
+	c.baseClient = client
+
+	// alks-go ignores a failed login role lookup, so make sure it wasn't
+	// because url isn't the ALKS API
+	if client.AccountDetails.Account == "" {
+		if err := checkAlksURL(client); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1. Check if calling for a specific account
+	if len(c.Account) > 0 && len(c.Role) > 0 {
+		// 2. Generate client specified
+		client, err = generateNewClient(ctx, c, client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if client != c.baseClient {
+		if err := c.verifySession(ctx, client); err != nil {
+			return nil, err
+		}
+	}
+
+	client.SetUserAgent(c.userAgent())
+
+	log.Println("[INFO] ALKS Client configured")
+
+	return client, nil
+}
+
+// baseCredentials resolves the AWS credentials to call ALKS with, before any
+// assume_role, from the provider block, the environment or a shared profile.
+func (c *Config) baseCredentials(ctx context.Context) (*credentials.Credentials, error) {
 	log.Println("[DEBUG] Validating STS credentials")
 
 	// lookup credentials
@@ -109,7 +183,7 @@ func (c *Config) Client() (*alks.Client, error) {
 	if cpErr != nil {
 		if awsErr, ok := cpErr.(awserr.Error); ok && awsErr.Code() == "NoCredentialProviders" {
 			var err error
-			creds, err = getCredentialsFromSession(c)
+			creds, err = getCredentialsFromSession(ctx, c)
 			if err != nil {
 				return nil, err
 			}
@@ -119,75 +193,109 @@ func (c *Config) Client() (*alks.Client, error) {
 	if cpErr != nil {
 		return nil, errNoValidCredentialSources
 	}
+	c.credentialSource = cp.ProviderName
+
+	return creds, nil
+}
 
+// stsConnection returns an STS client for creds, assuming the assume_role role
+// first if one is configured, along with the credentials to call ALKS with.
+func (c *Config) stsConnection(ctx context.Context, creds *credentials.Credentials) (*sts.STS, credentials.Value, error) {
 	// create a new session to test credentails
 	sess, err := session.NewSession(&aws.Config{
 		Region:      aws.String("us-east-1"),
 		Credentials: creds,
+		Endpoint:    aws.String(c.STSEndpoint),
 	})
 
 	// validate session
 	if err != nil {
-		return nil, fmt.Errorf("Error creating session from STS. (%v)", err)
+		return nil, credentials.Value{}, fmt.Errorf("Error creating session from STS. (%v)", err)
 	}
+	traceAWSRequests(ctx, &sess.Handlers)
 
-	var stsconn *sts.STS
 	// we need to assume another role before creating an ALKS client
-	if c.AssumeRole.RoleARN != "" {
-		arCreds := stscreds.NewCredentials(sess, c.AssumeRole.RoleARN, func(p *stscreds.AssumeRoleProvider) {
-			if c.AssumeRole.SessionName != "" {
-				p.RoleSessionName = c.AssumeRole.SessionName
-			}
+	if c.AssumeRole.RoleARN == "" {
+		cp, err := creds.Get()
+		if err != nil {
+			return nil, credentials.Value{}, errNoValidCredentialSources
+		}
 
-			if c.AssumeRole.ExternalID != "" {
-				p.ExternalID = &c.AssumeRole.ExternalID
-			}
+		return sts.New(sess), cp, nil
+	}
 
-			if c.AssumeRole.Policy != "" {
-				p.Policy = &c.AssumeRole.Policy
-			}
-		})
+	arCreds := stscreds.NewCredentials(sess, c.AssumeRole.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+		if c.AssumeRole.SessionName != "" {
+			p.RoleSessionName = c.AssumeRole.SessionName
+		}
+
+		if c.AssumeRole.ExternalID != "" {
+			p.ExternalID = &c.AssumeRole.ExternalID
+		}
 
-		cp, cpErr = arCreds.Get()
-		if cpErr != nil {
-			return nil, fmt.Errorf("The role %q cannot be assumed. Please verify the role ARN, role policies and your base AWS credentials", c.AssumeRole.RoleARN)
+		if c.AssumeRole.Policy != "" {
+			p.Policy = &c.AssumeRole.Policy
 		}
+	})
 
-		stsconn = sts.New(sess, &aws.Config{
-			Region:      aws.String("us-east-1"),
-			Credentials: arCreds,
-		})
-	} else {
-		stsconn = sts.New(sess)
+	cp, err := arCreds.Get()
+	if err != nil {
+		return nil, credentials.Value{}, fmt.Errorf("The role %q cannot be assumed. Please verify the role ARN, role policies and your base AWS credentials", c.AssumeRole.RoleARN)
 	}
 
-	// make a basic api call to test creds are valid
-	_, serr := stsconn.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	stsconn := sts.New(sess, &aws.Config{
+		Region:      aws.String("us-east-1"),
+		Credentials: arCreds,
+	})
+
+	return stsconn, cp, nil
+}
+
+// verifyCredentials checks the credentials behind stsconn with STS and records
+// who they belong to.
+func (c *Config) verifyCredentials(stsconn *sts.STS) error {
+	identity, err := stsconn.GetCallerIdentity(&sts.GetCallerIdentityInput{})
 	// check for valid creds
-	if serr != nil {
-		return nil, serr
+	if err != nil {
+		return err
 	}
+	c.baseIdentity = identity
+	c.callerIdentity = identity
 
-	// got good creds, create alks sts client
-	client, err := alks.NewSTSClient(c.URL, cp.AccessKeyID, cp.SecretAccessKey, cp.SessionToken)
-	if err != nil {
-		return nil, err
+	return nil
+}
+
+// verifySession looks up the identity of the session client was switched to
+// with STS, so callerIdentity describes the client Client() returns.
+func (c *Config) verifySession(ctx context.Context, client *alks.Client) error {
+	creds, ok := client.Credentials.(*alks.STS)
+	if !ok {
+		return fmt.Errorf("Cannot verify the session for account %s: the ALKS client has no STS credentials", client.AccountDetails.Account)
 	}
 
-	// 1. Check if calling for a specific account
-	if len(c.Account) > 0 && len(c.Role) > 0 {
-		// 2. Generate client specified
-		client, err = generateNewClient(c, client)
-		if err != nil {
-			return nil, err
-		}
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials(creds.AccessKey, creds.SecretKey, creds.SessionToken),
+		Endpoint:    aws.String(c.STSEndpoint),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating session from STS. (%v)", err)
 	}
+	traceAWSRequests(ctx, &sess.Handlers)
 
-	client.SetUserAgent(fmt.Sprintf("alks-terraform-provider-%s", getPluginVersion()))
+	identity, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return fmt.Errorf("Error verifying the session for account %s: %s", client.AccountDetails.Account, err)
+	}
+	c.callerIdentity = identity
 
-	log.Println("[INFO] ALKS Client configured")
+	return nil
+}
 
-	return client, nil
+// userAgent returns the user agent sent to ALKS, followed by any extra products
+// configured with the provider's user_agent argument.
+func (c *Config) userAgent() string {
+	return appendUserAgent(fmt.Sprintf("alks-terraform-provider-%s", getPluginVersion()), c.UserAgent...)
 }
 
 func getPluginVersion() string {
@@ -198,27 +306,79 @@ func getPluginVersion() string {
 	return "unknown"
 }
 
-func generateNewClient(c *Config, client *alks.Client) (*alks.Client, error) {
+func generateNewClient(ctx context.Context, c *Config, client *alks.Client) (*alks.Client, error) {
+	newClient, err := newAccountClient(ctx, c.URL, c.Account, c.Role, client)
+	if err != nil {
+		return nil, err
+	}
 
-	// 3. Create account string
-	newAccDetail := c.Account + "/ALKS" + c.Role
+	return newClient, nil
+}
+
+// newAccountClient mints an ALKS client for account and role from the session
+// of client, which is left untouched.
+func newAccountClient(ctx context.Context, url string, account string, role string, client *alks.Client) (*alks.Client, *alks.AlksError) {
+
+	// alks-go leaves the account empty when it couldn't look up the login role,
+	// and then there's no telling whether client is already in account
+	if client.AccountDetails.Account == "" {
+		return nil, &alks.AlksError{Err: fmt.Errorf("Cannot switch to account %s and role %s: ALKS did not return the account and role of the provider's credentials", account, role)}
+	}
 
 	// Calling for the same account; exit early
-	if strings.Contains(newAccDetail, client.AccountDetails.Account) {
+	if isAccountRole(client.AccountDetails, account, role) {
 		return client, nil
 	}
 
+	// 3. Create account string
+	newAccDetail := account + "/ALKS" + role
+
 	// 4. Alright, new credentials needed - swap em out.
-	client.AccountDetails.Account = newAccDetail
-	client.AccountDetails.Role = c.Role
+	sessionClient := *client
+	sessionClient.AccountDetails.Account = newAccDetail
+	sessionClient.AccountDetails.Role = role
 
-	newCreds, err := client.CreateIamSession()
+	newCreds, err := traceAlksCall(ctx, &sessionClient, "CreateIamSession", sessionClient.CreateIamSession)
 	if err != nil {
 		return nil, err
 	}
 
-	newClient, _ := alks.NewSTSClient(c.URL, newCreds.AccessKey, newCreds.SecretKey, newCreds.SessionToken)
+	newClient, stsErr := newTracedSTSClient(ctx, url, newCreds.AccessKey, newCreds.SecretKey, newCreds.SessionToken)
+	if stsErr != nil {
+		return nil, &alks.AlksError{Err: stsErr}
+	}
 
 	// 5. Return this new client for provider
 	return newClient, nil
 }
+
+// isAccountRole reports whether details, as returned by ALKS, are for account
+// and role.
+func isAccountRole(details alks.AccountDetails, account string, role string) bool {
+	roleName, err := details.GetRoleName(true)
+	if err != nil {
+		return false
+	}
+
+	return accountNumber(details.Account) == accountNumber(account) && roleName == strings.TrimPrefix(role, "ALKS")
+}
+
+// newTracedSTSClient creates an ALKS STS client inside a span, since the client
+// looks up the caller's login role from ALKS as it's created.
+func newTracedSTSClient(ctx context.Context, url string, accessKey string, secretKey string, token string) (*alks.Client, error) {
+	span := startAlksSpan(ctx, nil, "NewSTSClient")
+	defer span.End()
+
+	client, err := alks.NewSTSClient(url, accessKey, secretKey, token)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attrAlksAccount.String(client.AccountDetails.Account),
+		attrAlksRole.String(client.AccountDetails.Role),
+	)
+
+	return client, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+// credentialProcessOutput is the document the AWS CLI and SDKs expect from a
+// credential_process command.
+type credentialProcessOutput struct {
+	Version         int    `json:"Version"`
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	SessionToken    string `json:"SessionToken"`
+	Expiration      string `json:"Expiration,omitempty"`
+}
+
+// sessionEnv returns session as the environment variables AWS tools read.
+func sessionEnv(session *alks.SessionResponse) map[string]string {
+	return map[string]string{
+		"AWS_ACCESS_KEY_ID":     session.AccessKey,
+		"AWS_SECRET_ACCESS_KEY": session.SecretKey,
+		"AWS_SESSION_TOKEN":     session.SessionToken,
+	}
+}
+
+// sessionCredentialProcess returns session as version 1 credential_process JSON.
+func sessionCredentialProcess(session *alks.SessionResponse) (string, error) {
+	out := credentialProcessOutput{
+		Version:         1,
+		AccessKeyID:     session.AccessKey,
+		SecretAccessKey: session.SecretKey,
+		SessionToken:    session.SessionToken,
+	}
+	if !session.Expires.IsZero() {
+		out.Expiration = session.Expires.UTC().Format(time.RFC3339)
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// sessionSharedCredentials returns session as a profile for the AWS shared
+// credentials file.
+func sessionSharedCredentials(profile string, session *alks.SessionResponse) string {
+	return fmt.Sprintf("[%s]\naws_access_key_id = %s\naws_secret_access_key = %s\naws_session_token = %s\n",
+		profile, session.AccessKey, session.SecretKey, session.SessionToken)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+func TestSessionCredentialFormats(t *testing.T) {
+	session := &alks.SessionResponse{
+		AccessKey:    "AKIA",
+		SecretKey:    "secret",
+		SessionToken: "token",
+		Expires:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("EST", -5*60*60)),
+	}
+
+	env := sessionEnv(session)
+	if env["AWS_ACCESS_KEY_ID"] != "AKIA" || env["AWS_SECRET_ACCESS_KEY"] != "secret" || env["AWS_SESSION_TOKEN"] != "token" {
+		t.Fatalf("Unexpected env: %v", env)
+	}
+
+	credentialProcess, err := sessionCredentialProcess(session)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal([]byte(credentialProcess), &out); err != nil {
+		t.Fatalf("Invalid credential_process JSON %q: %s", credentialProcess, err)
+	}
+	if out["Version"] != float64(1) || out["AccessKeyId"] != "AKIA" || out["Expiration"] != "2024-01-02T08:04:05Z" {
+		t.Fatalf("Unexpected credential_process JSON: %s", credentialProcess)
+	}
+
+	session.Expires = time.Time{}
+	if credentialProcess, _ = sessionCredentialProcess(session); credentialProcess != `{"Version":1,"AccessKeyId":"AKIA","SecretAccessKey":"secret","SessionToken":"token"}` {
+		t.Fatalf("Expected no Expiration without an expiry, got %s", credentialProcess)
+	}
+
+	expected := "[dev]\naws_access_key_id = AKIA\naws_secret_access_key = secret\naws_session_token = token\n"
+	if ini := sessionSharedCredentials("dev", session); ini != expected {
+		t.Fatalf("Expected %q, got %q", expected, ini)
+	}
+}
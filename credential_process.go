@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/mitchellh/go-homedir"
+)
+
+// credentialProcessCommand is the argument that runs the provider binary as an
+// AWS credential_process instead of a Terraform plugin.
+const credentialProcessCommand = "credential-process"
+
+// runCredentialProcess resolves credentials the same way the provider block
+// does and prints an ALKS session for them as credential_process JSON.
+func runCredentialProcess(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	config, verbose, err := commandConfig(credentialProcessCommand, args, stderr)
+	if err != nil {
+		return err
+	}
+
+	// The AWS CLI shows anything on stderr to the user
+	if verbose {
+		log.SetOutput(stderr)
+	} else {
+		log.SetOutput(io.Discard)
+	}
+
+	client, err := config.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	return writeCredentialProcess(ctx, client, stdout)
+}
+
+// commandConfig parses the flags of a subcommand of the provider binary into
+// a Config. Flags default to the environment variables the provider block reads.
+func commandConfig(command string, args []string, stderr io.Writer) (*Config, bool, error) {
+	config := &Config{}
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	flags.StringVar(&config.URL, "url", os.Getenv("ALKS_URL"), "The base URL of the ALKS service.")
+	flags.StringVar(&config.Account, "account", os.Getenv("Account"), "The account to switch to, instead of the caller's.")
+	flags.StringVar(&config.Role, "role", os.Getenv("Role"), "The role to switch to in -account.")
+	flags.StringVar(&config.Profile, "profile", os.Getenv("AWS_PROFILE"), "The AWS profile to call ALKS with.")
+	flags.StringVar(&config.CredsFilename, "shared-credentials-file", os.Getenv("AWS_SHARED_CREDENTIALS_FILE"), "The path to the shared credentials file. Defaults to ~/.aws/credentials.")
+	flags.StringVar(&config.AssumeRole.RoleARN, "assume-role-arn", "", "A role to assume before calling ALKS.")
+	flags.StringVar(&config.AssumeRole.SessionName, "assume-role-session-name", "", "The session name to use when assuming -assume-role-arn.")
+	flags.StringVar(&config.AssumeRole.ExternalID, "assume-role-external-id", "", "The external ID to use when assuming -assume-role-arn.")
+	verbose := flags.Bool("verbose", false, "Write the provider's logs to stderr.")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, false, err
+	}
+	if flags.NArg() > 0 {
+		return nil, false, fmt.Errorf("Unexpected arguments: %v", flags.Args())
+	}
+	if config.URL == "" {
+		return nil, false, fmt.Errorf("-url must be set, or ALKS_URL exported")
+	}
+	url, err := normalizeAlksURL(config.URL)
+	if err != nil {
+		return nil, false, err
+	}
+	config.URL = url
+	if (config.Account == "") != (config.Role == "") {
+		return nil, false, fmt.Errorf("-account and -role must be set together")
+	}
+
+	credsPath, err := homedir.Expand(config.CredsFilename)
+	if err != nil {
+		return nil, false, err
+	}
+	config.CredsFilename = credsPath
+	config.UserAgent = []string{command}
+
+	return config, *verbose, nil
+}
+
+// writeCredentialProcess mints an IAM session with client and writes it to w.
+func writeCredentialProcess(ctx context.Context, client *alks.Client, w io.Writer) error {
+	session, err := traceAlksCall(ctx, client, "CreateIamSession", client.CreateIamSession)
+	if err != nil {
+		return err
+	}
+
+	out, jsonErr := sessionCredentialProcess(session)
+	if jsonErr != nil {
+		return jsonErr
+	}
+
+	_, writeErr := fmt.Fprintln(w, out)
+	return writeErr
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+func TestCommandConfig(t *testing.T) {
+	t.Setenv("ALKS_URL", "https://alks.example.com/rest")
+	t.Setenv("Account", "")
+	t.Setenv("Role", "")
+
+	config, verbose, err := commandConfig(credentialProcessCommand, []string{"-account", "012345678910", "-role", "Admin", "-assume-role-arn", "arn:aws:iam::012345678910:role/ci"}, io.Discard)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if config.URL != "https://alks.example.com/rest" || config.Account != "012345678910" || config.Role != "Admin" || verbose {
+		t.Fatalf("Unexpected config: %+v", config)
+	}
+	if config.AssumeRole.RoleARN != "arn:aws:iam::012345678910:role/ci" {
+		t.Fatalf("Expected the assume role ARN to be set, got %q", config.AssumeRole.RoleARN)
+	}
+
+	for _, args := range [][]string{
+		{"-account", "012345678910"},
+		{"extra"},
+		{"-unknown"},
+	} {
+		if _, _, err := commandConfig(credentialProcessCommand, args, io.Discard); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+
+	t.Setenv("ALKS_URL", "")
+	if _, _, err := commandConfig(credentialProcessCommand, nil, io.Discard); err == nil {
+		t.Errorf("Expected an error without a URL")
+	}
+}
+
+func TestWriteCredentialProcess(t *testing.T) {
+	fake := newTestFakeAlks(t)
+
+	var out bytes.Buffer
+	if err := writeCredentialProcess(context.Background(), fake.client(t), &out); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var doc credentialProcessOutput
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON %q: %s", out.String(), err)
+	}
+	session, _ := fake.session(doc.AccessKeyID)
+	if doc.Version != 1 || session.Account != fakeAlksAccount+"/ALKS"+fakeAlksRole || !session.iam || doc.SecretAccessKey != "secret" || doc.Expiration == "" {
+		t.Fatalf("Unexpected credential_process output: %s", out.String())
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlksAccounts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlksAccountsRead,
+		Schema: map[string]*schema.Schema{
+			"alias": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"iam_active_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"accounts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"iam_key_active": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"alias": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAlksAccountsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ALKS Accounts Data Source Read")
+
+	providerStruct := meta.(*AlksClient)
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var alias = d.Get("alias").(string)
+	var role = d.Get("role").(string)
+	var iamActiveOnly = d.Get("iam_active_only").(bool)
+
+	resp, err := traceAlksCall(ctx, client, "GetAccounts", client.GetAccounts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	accountRoles := resp.Accounts
+	sort.Slice(accountRoles, func(i, j int) bool {
+		if accountRoles[i].Account != accountRoles[j].Account {
+			return accountRoles[i].Account < accountRoles[j].Account
+		}
+		return accountRoles[i].Role < accountRoles[j].Role
+	})
+
+	accounts := []map[string]interface{}{}
+	for _, accountRole := range accountRoles {
+		if alias != "" && accountRole.SkypieaAccount.Alias != alias {
+			continue
+		}
+		if role != "" && accountRole.Role != role {
+			continue
+		}
+		if iamActiveOnly && !accountRole.IamActive {
+			continue
+		}
+
+		accounts = append(accounts, map[string]interface{}{
+			"account":        accountNumber(accountRole.Account),
+			"role":           accountRole.Role,
+			"iam_key_active": accountRole.IamActive,
+			"alias":          accountRole.SkypieaAccount.Alias,
+			"label":          accountRole.SkypieaAccount.Label,
+		})
+	}
+
+	d.SetId(client.AccountDetails.Account)
+	if err := d.Set("accounts", accounts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// accountNumber returns the 12 digit AWS account number from an ALKS account
+// string such as "012345678910/ALKSAdmin - alias".
+func accountNumber(account string) string {
+	if len(account) < 12 {
+		return account
+	}
+
+	return account[:12]
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+func TestDataSourceAlksAccountsRead(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	fake.addAccount("109876543210/ALKSReadOnly - bar", alks.AccountRole{Role: "ReadOnly", SkypieaAccount: alks.SkypieaAccount{Alias: "bar", Label: "Bar Prod"}})
+	fake.addAccount("012345678910/ALKSLabAdmin - foo", alks.AccountRole{Role: "LabAdmin", IamActive: true, SkypieaAccount: alks.SkypieaAccount{Alias: "foo", Label: "Foo Dev"}})
+	meta := &AlksClient{client: fake.client(t)}
+
+	cases := []struct {
+		name     string
+		filters  map[string]interface{}
+		expected []string
+	}{
+		{"no filters", nil, []string{"012345678910/Admin", "012345678910/LabAdmin", "109876543210/ReadOnly"}},
+		{"alias", map[string]interface{}{"alias": "bar"}, []string{"109876543210/ReadOnly"}},
+		{"role", map[string]interface{}{"role": "LabAdmin"}, []string{"012345678910/LabAdmin"}},
+		{"iam active only", map[string]interface{}{"iam_active_only": true}, []string{"012345678910/Admin", "012345678910/LabAdmin"}},
+	}
+
+	for _, c := range cases {
+		d := dataSourceAlksAccounts().TestResourceData()
+		for k, v := range c.filters {
+			_ = d.Set(k, v)
+		}
+
+		if diags := dataSourceAlksAccountsRead(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("%s: unexpected error: %#v", c.name, diags)
+		}
+
+		accounts := d.Get("accounts").([]interface{})
+		if len(accounts) != len(c.expected) {
+			t.Fatalf("%s: expected %v, got %v", c.name, c.expected, accounts)
+		}
+		for i, expected := range c.expected {
+			account := accounts[i].(map[string]interface{})
+			if actual := fmt.Sprintf("%s/%s", account["account"], account["role"]); actual != expected {
+				t.Fatalf("%s: expected %s at %d, got %s", c.name, expected, i, actual)
+			}
+		}
+	}
+
+	d := dataSourceAlksAccounts().TestResourceData()
+	_ = d.Set("alias", "bar")
+	_ = dataSourceAlksAccountsRead(context.Background(), d, meta)
+	if label := d.Get("accounts.0.label"); label != "Bar Prod" {
+		t.Fatalf("Expected the account label, got %q", label)
+	}
+	if active := d.Get("accounts.0.iam_key_active"); active != false {
+		t.Fatalf("Expected iam_key_active to be false, got %v", active)
+	}
+}
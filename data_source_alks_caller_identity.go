@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlksCallerIdentity() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlksCallerIdentityRead,
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"alks_account": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"alks_role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"credential_source": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"assume_role_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAlksCallerIdentityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ALKS Caller Identity Data Source Read")
+
+	providerStruct := meta.(*AlksClient)
+	client := providerStruct.client
+
+	if providerStruct.callerIdentity == nil {
+		return diag.Errorf("The provider's caller identity is unknown")
+	}
+
+	d.SetId(aws.StringValue(providerStruct.callerIdentity.Arn))
+	_ = d.Set("arn", aws.StringValue(providerStruct.callerIdentity.Arn))
+	_ = d.Set("user_id", aws.StringValue(providerStruct.callerIdentity.UserId))
+	_ = d.Set("account_id", aws.StringValue(providerStruct.callerIdentity.Account))
+	_ = d.Set("alks_account", accountNumber(client.AccountDetails.Account))
+	_ = d.Set("alks_role", strings.Split(client.AccountDetails.Role, "/")[0])
+	_ = d.Set("credential_source", providerStruct.credentialSource)
+	_ = d.Set("assume_role_arn", providerStruct.assumeRoleArn)
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+func TestDataSourceAlksCallerIdentityRead(t *testing.T) {
+	meta := &AlksClient{
+		client: &alks.Client{AccountDetails: alks.AccountDetails{Account: "109876543210/ALKSLabAdmin - bar", Role: "LabAdmin"}},
+		callerIdentity: &sts.GetCallerIdentityOutput{
+			Arn:     aws.String("arn:aws:sts::012345678910:assumed-role/Admin/me"),
+			UserId:  aws.String("AROAEXAMPLE:me"),
+			Account: aws.String("012345678910"),
+		},
+		credentialSource: "EnvProvider",
+		assumeRoleArn:    "arn:aws:iam::012345678910:role/Admin",
+	}
+
+	d := dataSourceAlksCallerIdentity().TestResourceData()
+	if diags := dataSourceAlksCallerIdentityRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Unexpected error: %#v", diags)
+	}
+
+	expected := map[string]string{
+		"arn":               "arn:aws:sts::012345678910:assumed-role/Admin/me",
+		"user_id":           "AROAEXAMPLE:me",
+		"account_id":        "012345678910",
+		"alks_account":      "109876543210",
+		"alks_role":         "LabAdmin",
+		"credential_source": "EnvProvider",
+		"assume_role_arn":   "arn:aws:iam::012345678910:role/Admin",
+	}
+	for k, v := range expected {
+		if actual := d.Get(k); actual != v {
+			t.Fatalf("Expected %s to be %q, got %q", k, v, actual)
+		}
+	}
+}
+
+func TestDataSourceAlksCallerIdentityRead_AccountSwitch(t *testing.T) {
+	f := newTestFakeAlks(t)
+	meta := f.configure(t, map[string]interface{}{"account": "109876543210", "role": "LabAdmin"})
+
+	d := dataSourceAlksCallerIdentity().TestResourceData()
+	if diags := dataSourceAlksCallerIdentityRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Unexpected error: %#v", diags)
+	}
+
+	expected := map[string]string{
+		"arn":          "arn:aws:sts::109876543210:assumed-role/LabAdmin/fake",
+		"account_id":   "109876543210",
+		"alks_account": "109876543210",
+		"alks_role":    "LabAdmin",
+	}
+	for k, v := range expected {
+		if actual := d.Get(k); actual != v {
+			t.Fatalf("Expected %s to be %q, got %q", k, v, actual)
+		}
+	}
+}
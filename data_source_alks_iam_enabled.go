@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlksIamEnabled() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlksIamEnabledRead,
+		Schema: map[string]*schema.Schema{
+			"role_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: ValidRoleArn,
+			},
+			"iam_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"account": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAlksIamEnabledRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ALKS IAM Enabled Data Source Read")
+
+	providerStruct := meta.(*AlksClient)
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var roleArn = d.Get("role_arn").(string)
+
+	resp, err := traceAlksCall(ctx, client, "IsIamEnabled", func() (*alks.IsIamEnabledResponse, *alks.AlksError) {
+		return client.IsIamEnabled(roleArn)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// ALKS echoes back the account and role it resolved, but fall back to the
+	// client's own when it doesn't.
+	account := resp.AccountDetails.Account
+	role := resp.AccountDetails.Role
+	if account == "" {
+		account = client.AccountDetails.Account
+		role = client.AccountDetails.Role
+	}
+
+	if roleArn != "" {
+		d.SetId(roleArn)
+	} else {
+		d.SetId(account)
+	}
+	_ = d.Set("iam_enabled", resp.IamEnabled)
+	_ = d.Set("account", accountNumber(account))
+	_ = d.Set("role", role)
+
+	return nil
+}
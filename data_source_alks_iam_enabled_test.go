@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+func TestDataSourceAlksIamEnabledRead(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	miArn := fmt.Sprintf("arn:aws:iam::%s:role/acct-managed/my-mi", fakeAlksAccount)
+	fake.roles["my-mi"] = &alks.GetIamRoleResponse{RoleName: "my-mi", RoleArn: miArn, Exists: true, AlksAccess: true}
+	fake.setMachineIdentitiesIamActive(false)
+	meta := &AlksClient{client: fake.client(t)}
+
+	cases := []struct {
+		roleArn    string
+		iamEnabled bool
+		account    string
+		role       string
+	}{
+		{"", true, fakeAlksAccount, fakeAlksRole},
+		{miArn, false, fakeAlksAccount, "my-mi"},
+	}
+
+	for _, c := range cases {
+		d := dataSourceAlksIamEnabled().TestResourceData()
+		_ = d.Set("role_arn", c.roleArn)
+
+		if diags := dataSourceAlksIamEnabledRead(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("%q: unexpected error: %#v", c.roleArn, diags)
+		}
+
+		if d.Get("iam_enabled") != c.iamEnabled || d.Get("account") != c.account || d.Get("role") != c.role {
+			t.Fatalf("%q: got iam_enabled %v, account %q, role %q", c.roleArn, d.Get("iam_enabled"), d.Get("account"), d.Get("role"))
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlksIamRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlksIamRoleRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "arn"},
+				ValidateFunc: ValidRoleName,
+			},
+			"arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "arn"},
+				ValidateFunc: ValidRoleArn,
+			},
+			"ip_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"role_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"assume_role_policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"max_session_duration_in_seconds": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"enable_alks_access": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAlksIamRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ALKS IAM Role Data Source Read")
+
+	providerStruct := meta.(*AlksClient)
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	roleName := d.Get("name").(string)
+	if roleArn, ok := d.GetOk("arn"); ok {
+		roleName = roleNameFromArn(roleArn.(string))
+	}
+
+	foundRole, err := traceAlksCall(ctx, client, "GetIamRole", func() (*alks.GetIamRoleResponse, *alks.AlksError) {
+		return client.GetIamRole(roleName)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(foundRole.RoleName)
+	_ = d.Set("name", foundRole.RoleName)
+	_ = d.Set("arn", foundRole.RoleArn)
+	_ = d.Set("ip_arn", foundRole.RoleIPArn)
+	_ = d.Set("role_type", foundRole.RoleType)
+	_ = d.Set("max_session_duration_in_seconds", foundRole.MaxSessionDurationInSeconds)
+	_ = d.Set("enable_alks_access", foundRole.AlksAccess)
+
+	if foundRole.TrustPolicy != nil {
+		jsonStrPolicy, err := json.Marshal(foundRole.TrustPolicy)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_ = d.Set("assume_role_policy", string(jsonStrPolicy))
+	}
+
+	if err := d.Set("tags", removeIgnoredTags(tagSliceToMap(foundRole.Tags), *providerStruct.ignoreTags)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// roleNameFromArn returns the role name from a role ARN, dropping any path.
+func roleNameFromArn(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestRoleNameFromArn(t *testing.T) {
+	cases := map[string]string{
+		"arn:aws:iam::012345678910:role/my-role":              "my-role",
+		"arn:aws:iam::012345678910:role/acct-managed/my-role": "my-role",
+	}
+
+	for arn, expected := range cases {
+		if actual := roleNameFromArn(arn); actual != expected {
+			t.Fatalf("Expected role name %q from %q, got %q", expected, arn, actual)
+		}
+	}
+}
+
+func TestAccDataSourceAlksIamRole_Basic(t *testing.T) {
+	var resp alks.IamRoleResponse
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAlksIamRoleDestroy(&resp),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAlksIamRoleConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.alks_iamrole.by_name", "arn", "alks_iamrole.foo", "arn"),
+					resource.TestCheckResourceAttrPair(
+						"data.alks_iamrole.by_name", "ip_arn", "alks_iamrole.foo", "ip_arn"),
+					resource.TestCheckResourceAttr(
+						"data.alks_iamrole.by_name", "role_type", "Amazon EC2"),
+					resource.TestCheckResourceAttr(
+						"data.alks_iamrole.by_name", "tags.foo", "bar"),
+					resource.TestCheckResourceAttr(
+						"data.alks_iamrole.by_arn", "name", "foo"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceAlksIamRoleConfig = `
+	resource "alks_iamrole" "foo" {
+		name = "foo"
+		type = "Amazon EC2"
+		include_default_policies = false
+		tags = {
+			foo = "bar"
+		}
+	}
+	data "alks_iamrole" "by_name" {
+		name = alks_iamrole.foo.name
+	}
+	data "alks_iamrole" "by_arn" {
+		arn = alks_iamrole.foo.arn
+	}
+`
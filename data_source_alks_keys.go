@@ -1,59 +1,221 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceAlksKeys() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceAlksKeysRead,
+		ReadContext: dataSourceAlksKeysRead,
 		Schema: map[string]*schema.Schema{
 			"access_key": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 			"secret_key": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 			"session_token": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 			"account": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				RequiredWith:  []string{"role"},
+				ConflictsWith: []string{"machine_identity_arn"},
+				ValidateFunc:  ValidAccountNumber,
 			},
 			"role": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				RequiredWith:  []string{"account"},
+				ConflictsWith: []string{"machine_identity_arn"},
+			},
+			"machine_identity_arn": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"account", "role"},
+				ValidateFunc:  ValidRoleArn,
+			},
+			"duration_hours": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"use_iam": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"expiration": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"session_duration": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"profile": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+			},
+			"env": {
+				Type:      schema.TypeMap,
+				Computed:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString},
+			},
+			"credential_process": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"shared_credentials": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
 		},
 	}
 }
 
-func dataSourceAlksKeysRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceAlksKeysRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] ALKS Keys Data Source Read")
+	if diags := checkReadOnly(meta, "create ALKS session keys"); diags != nil {
+		return diags
+	}
 
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
-	resp, err := client.CreateIamSession()
+	client := providerStruct.attributedClient(d, providerStruct.client)
 
+	resp, client, err := providerStruct.sessions.get(ctx, client, keysRequest{
+		account:            d.Get("account").(string),
+		role:               d.Get("role").(string),
+		machineIdentityArn: d.Get("machine_identity_arn").(string),
+		durationHours:      d.Get("duration_hours").(int),
+		useIAM:             d.Get("use_iam").(bool),
+	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Return the information to user.
 	_ = d.Set("access_key", resp.AccessKey)
 	_ = d.Set("secret_key", resp.SecretKey)
 	_ = d.Set("session_token", resp.SessionToken)
-	_ = d.Set("account", client.AccountDetails.Account)
-	_ = d.Set("role", strings.Split(client.AccountDetails.Role, "/")[0])
+	_ = d.Set("session_duration", resp.SessionDuration)
+	if !resp.Expires.IsZero() {
+		_ = d.Set("expiration", resp.Expires.UTC().Format(time.RFC3339))
+	}
+
+	credentialProcess, err := sessionCredentialProcess(resp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("env", sessionEnv(resp))
+	_ = d.Set("credential_process", credentialProcess)
+	_ = d.Set("shared_credentials", sessionSharedCredentials(d.Get("profile").(string), resp))
+	if _, ok := d.GetOk("account"); !ok {
+		_ = d.Set("account", client.AccountDetails.Account)
+		_ = d.Set("role", strings.Split(client.AccountDetails.Role, "/")[0])
+	}
 
 	d.SetId(client.AccountDetails.Account)
 
 	return nil
 }
+
+// keysRequest is the session an alks_keys data source or ephemeral resource asks for.
+type keysRequest struct {
+	account            string
+	role               string
+	machineIdentityArn string
+	durationHours      int
+	useIAM             bool
+}
+
+// mintKeys creates the session described by req with client's credentials.
+// It returns the session and the client it was minted for, which carries the
+// target account and role when the request named one.
+func mintKeys(ctx context.Context, client *alks.Client, req keysRequest) (*alks.SessionResponse, *alks.Client, error) {
+	account, role := req.account, req.role
+
+	if req.machineIdentityArn != "" {
+		// ALKS resolves the account and role a machine identity maps to
+		resp, err := traceAlksCall(ctx, client, "IsIamEnabled", func() (*alks.IsIamEnabledResponse, *alks.AlksError) {
+			return client.IsIamEnabled(req.machineIdentityArn)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		// alks-go sends the caller's account details along with the ARN, so
+		// an answer echoing them back didn't resolve the machine identity
+		if resp.AccountDetails.Account == "" || resp.AccountDetails == client.AccountDetails {
+			return nil, nil, fmt.Errorf("ALKS did not resolve machine identity %s to an account and role", req.machineIdentityArn)
+		}
+
+		account, role = resp.AccountDetails.Account, resp.AccountDetails.Role
+	}
+
+	if account != "" {
+		// Mint the session with the provider's credentials, on behalf of the target
+		target := *client
+		target.AccountDetails = alks.AccountDetails{
+			Account: targetAccount(account, role),
+			Role:    role,
+		}
+		client = &target
+	}
+
+	durations, err := traceAlksCall(ctx, client, "Durations", func() ([]int, *alks.AlksError) {
+		durations, err := client.Durations()
+		if err != nil {
+			return nil, &alks.AlksError{Err: err}
+		}
+		return durations, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if !slices.Contains(durations, req.durationHours) {
+		return nil, nil, fmt.Errorf("duration_hours of %d is not allowed for %s, allowed durations are %v", req.durationHours, client.AccountDetails.Account, durations)
+	}
+
+	resp, err := traceAlksCall(ctx, client, "CreateSession", func() (*alks.SessionResponse, *alks.AlksError) {
+		return client.CreateSession(req.durationHours, req.useIAM)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, client, nil
+}
+
+// targetAccount returns the ALKS account string for a role in account, which
+// may be a bare account number or already an ALKS account string.
+func targetAccount(account string, role string) string {
+	if strings.Contains(account, "/") {
+		return account
+	}
+
+	return account + "/ALKS" + role
+}
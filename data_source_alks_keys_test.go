@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceAlksKeysRead_Targets(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	miArn := "arn:aws:iam::109876543210:role/acct-managed/my-mi"
+	fake.roles["my-mi"] = &alks.GetIamRoleResponse{RoleName: "my-mi", RoleArn: miArn, Exists: true, AlksAccess: true}
+	client := fake.client(t)
+	meta := &AlksClient{client: client}
+
+	cases := []struct {
+		name           string
+		config         map[string]interface{}
+		sessionAccount string
+		account        string
+		role           string
+	}{
+		{"provider", nil, fakeAlksAccount + "/ALKS" + fakeAlksRole, fakeAlksAccount + "/ALKS" + fakeAlksRole, fakeAlksRole},
+		{"account and role", map[string]interface{}{"account": "109876543210", "role": "LabAdmin"}, "109876543210/ALKSLabAdmin", "109876543210", "LabAdmin"},
+		{"machine identity", map[string]interface{}{"machine_identity_arn": miArn}, "109876543210/ALKSmy-mi", "109876543210/ALKSmy-mi", "my-mi"},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, dataSourceAlksKeys().Schema, c.config)
+
+		if diags := dataSourceAlksKeysRead(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("%s: unexpected error: %#v", c.name, diags)
+		}
+
+		session, ok := fake.session(d.Get("access_key").(string))
+		if !ok || session.Account != c.sessionAccount || d.Id() != c.sessionAccount {
+			t.Fatalf("%s: expected keys for %q, got %+v with id %q", c.name, c.sessionAccount, session, d.Id())
+		}
+		if d.Get("account") != c.account || d.Get("role") != c.role {
+			t.Fatalf("%s: expected account %q and role %q, got %q and %q", c.name, c.account, c.role, d.Get("account"), d.Get("role"))
+		}
+	}
+
+	if client.AccountDetails.Account != fakeAlksAccount+"/ALKS"+fakeAlksRole {
+		t.Fatalf("Expected the provider's client to be left untouched, got account %q", client.AccountDetails.Account)
+	}
+}
+
+func TestDataSourceAlksKeysRead_UnresolvedMachineIdentity(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	meta := &AlksClient{client: fake.client(t)}
+
+	// the fake echoes the caller's account details for an ARN that isn't a
+	// machine identity, which mustn't mint keys for the caller instead
+	d := schema.TestResourceDataRaw(t, dataSourceAlksKeys().Schema, map[string]interface{}{"machine_identity_arn": "arn:aws:iam::109876543210:role/acct-managed/not-an-mi"})
+	diags := dataSourceAlksKeysRead(context.Background(), d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "did not resolve machine identity") {
+		t.Fatalf("Expected an unresolved machine identity error, got %#v", diags)
+	}
+}
+
+func TestDataSourceAlksKeysRead_Duration(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	fake.setMaxKeyDuration(2)
+	meta := &AlksClient{client: fake.client(t)}
+
+	d := schema.TestResourceDataRaw(t, dataSourceAlksKeys().Schema, map[string]interface{}{})
+	if diags := dataSourceAlksKeysRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Unexpected error: %#v", diags)
+	}
+	if session, _ := fake.session(d.Get("access_key").(string)); d.Get("session_duration") != 1 || !session.iam {
+		t.Fatalf("Expected a 1 hour IAM session by default, got %v hours, IAM %t", d.Get("session_duration"), session.iam)
+	}
+	expiration, err := time.Parse(time.RFC3339, d.Get("expiration").(string))
+	if err != nil || time.Until(expiration) < 59*time.Minute || time.Until(expiration) > time.Hour {
+		t.Fatalf("Expected the keys to expire in an hour, got %q", d.Get("expiration"))
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceAlksKeys().Schema, map[string]interface{}{"duration_hours": 2, "use_iam": false})
+	if diags := dataSourceAlksKeysRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Unexpected error: %#v", diags)
+	}
+	if session, _ := fake.session(d.Get("access_key").(string)); d.Get("session_duration") != 2 || session.iam {
+		t.Fatalf("Expected a 2 hour non-IAM session, got %v hours, IAM %t", d.Get("session_duration"), session.iam)
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceAlksKeys().Schema, map[string]interface{}{"duration_hours": 3})
+	diags := dataSourceAlksKeysRead(context.Background(), d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "duration_hours of 3 is not allowed") || !strings.Contains(diags[0].Summary, "allowed durations are [1 2]") {
+		t.Fatalf("Expected an error listing the allowed durations, got %#v", diags)
+	}
+
+	fake.override("POST", "/getIAMKeys/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errors": ["expired token"]}`)
+	})
+	d = schema.TestResourceDataRaw(t, dataSourceAlksKeys().Schema, map[string]interface{}{})
+	diags = dataSourceAlksKeysRead(context.Background(), d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "expired token") || strings.Contains(diags[0].Summary, "duration") {
+		t.Fatalf("Expected ALKS's error to be passed through, got %#v", diags)
+	}
+}
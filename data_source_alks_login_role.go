@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlksLoginRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlksLoginRoleRead,
+		Schema: map[string]*schema.Schema{
+			"account": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"iam_key_active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"max_key_duration": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"durations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func dataSourceAlksLoginRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ALKS Login Role Data Source Read")
+
+	providerStruct := meta.(*AlksClient)
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := traceAlksCall(ctx, client, "GetLoginRole", client.GetLoginRole)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	durations, err := traceAlksCall(ctx, client, "Durations", func() ([]int, *alks.AlksError) {
+		durations, err := client.Durations()
+		if err != nil {
+			return nil, &alks.AlksError{Err: err}
+		}
+		return durations, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.LoginRole.Account)
+	_ = d.Set("account", accountNumber(resp.LoginRole.Account))
+	_ = d.Set("role", resp.LoginRole.Role)
+	_ = d.Set("iam_key_active", resp.LoginRole.IamKeyActive)
+	_ = d.Set("max_key_duration", resp.LoginRole.MaxKeyDuration)
+	if err := d.Set("durations", durations); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDataSourceAlksLoginRoleRead(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	fake.setMaxKeyDuration(4)
+
+	d := dataSourceAlksLoginRole().TestResourceData()
+	if diags := dataSourceAlksLoginRoleRead(context.Background(), d, &AlksClient{client: fake.client(t)}); diags.HasError() {
+		t.Fatalf("Unexpected error: %#v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"account":          fakeAlksAccount,
+		"role":             fakeAlksRole,
+		"iam_key_active":   true,
+		"max_key_duration": 4,
+		"durations":        []interface{}{1, 2, 3, 4},
+	}
+	for k, v := range expected {
+		if actual := d.Get(k); !reflect.DeepEqual(actual, v) {
+			t.Fatalf("Expected %s to be %v, got %v", k, v, actual)
+		}
+	}
+}
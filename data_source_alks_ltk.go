@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlksLtk() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlksLtkRead,
+		Schema: map[string]*schema.Schema{
+			"iam_username": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"iam_user_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"access_key_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAlksLtkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ALKS LTK Data Source Read")
+
+	providerStruct := meta.(*AlksClient)
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var iamUsername = d.Get("iam_username").(string)
+
+	resp, err := traceAlksCall(ctx, client, "GetIamUser", func() (*alks.GetIamUserResponse, *alks.AlksError) {
+		return client.GetIamUser(iamUsername)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.User.UserName)
+	_ = d.Set("iam_user_arn", resp.User.ARN)
+	_ = d.Set("access_key_id", resp.User.AccessKey)
+
+	if err := d.Set("tags", removeIgnoredTags(tagSliceToMap(resp.User.Tags), *providerStruct.ignoreTags)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
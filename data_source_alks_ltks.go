@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceAlksLtks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlksLtksRead,
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Active", "Inactive"}, false),
+			},
+			"iam_usernames": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"ltks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"iam_username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"access_key_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"create_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAlksLtksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ALKS LTKs Data Source Read")
+
+	providerStruct := meta.(*AlksClient)
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var namePrefix = d.Get("name_prefix").(string)
+	var status = d.Get("status").(string)
+
+	resp, err := traceAlksCall(ctx, client, "GetIamUsers", client.GetIamUsers)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	usernames := []string{}
+	ltks := []map[string]interface{}{}
+	for _, user := range resp.IamUsers {
+		if !strings.HasPrefix(user.UserName, namePrefix) {
+			continue
+		}
+		if status != "" && user.Status != status {
+			continue
+		}
+
+		usernames = append(usernames, user.UserName)
+		ltks = append(ltks, map[string]interface{}{
+			"iam_username":  user.UserName,
+			"access_key_id": user.AccessKeyID,
+			"status":        user.Status,
+			"create_date":   user.CreateDate,
+		})
+	}
+
+	d.SetId(client.AccountDetails.Account)
+	_ = d.Set("iam_usernames", usernames)
+	if err := d.Set("ltks", ltks); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
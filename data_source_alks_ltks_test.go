@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+// newTestLtksFake starts a fake ALKS with three LTK users, one of them tagged.
+func newTestLtksFake(t *testing.T) *fakeAlks {
+	fake := newTestFakeAlks(t)
+	for i, user := range []struct{ name, status, createDate string }{
+		{"ci-deploy", "Active", "2023-01-01T00:00:00Z"},
+		{"ci-legacy", "Inactive", "2020-01-01T00:00:00Z"},
+		{"vendor-sync", "Active", "2022-01-01T00:00:00Z"},
+	} {
+		fake.users[user.name] = &fakeUser{
+			IamUser: alks.IamUser{
+				ARN:       fmt.Sprintf("arn:aws:iam::%s:user/acct-managed/%s", fakeAlksAccount, user.name),
+				AccountId: fakeAlksAccount,
+				UserName:  user.name,
+				AccessKey: fmt.Sprintf("AKIA%d", i+1),
+			},
+			status:     user.status,
+			createDate: user.createDate,
+		}
+	}
+	fake.users["ci-deploy"].Tags = []alks.Tag{{Key: "team", Value: "ci"}, {Key: "ignored", Value: "x"}}
+
+	return fake
+}
+
+func TestDataSourceAlksLtksRead(t *testing.T) {
+	fake := newTestLtksFake(t)
+	meta := &AlksClient{client: fake.client(t)}
+
+	cases := []struct {
+		name     string
+		filters  map[string]interface{}
+		expected []interface{}
+	}{
+		{"no filters", nil, []interface{}{"ci-deploy", "ci-legacy", "vendor-sync"}},
+		{"name prefix", map[string]interface{}{"name_prefix": "ci-"}, []interface{}{"ci-deploy", "ci-legacy"}},
+		{"status", map[string]interface{}{"status": "Active"}, []interface{}{"ci-deploy", "vendor-sync"}},
+	}
+
+	for _, c := range cases {
+		d := dataSourceAlksLtks().TestResourceData()
+		for k, v := range c.filters {
+			_ = d.Set(k, v)
+		}
+
+		if diags := dataSourceAlksLtksRead(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("%s: unexpected error: %#v", c.name, diags)
+		}
+
+		if actual := d.Get("iam_usernames"); !reflect.DeepEqual(actual, c.expected) {
+			t.Fatalf("%s: expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+
+	d := dataSourceAlksLtks().TestResourceData()
+	_ = d.Set("name_prefix", "ci-legacy")
+	_ = dataSourceAlksLtksRead(context.Background(), d, meta)
+	if actual := d.Get("ltks.0").(map[string]interface{}); actual["access_key_id"] != "AKIA2" || actual["status"] != "Inactive" || actual["create_date"] != "2020-01-01T00:00:00Z" {
+		t.Fatalf("Unexpected LTK %v", actual)
+	}
+}
+
+func TestDataSourceAlksLtkRead(t *testing.T) {
+	fake := newTestLtksFake(t)
+	meta := &AlksClient{client: fake.client(t), ignoreTags: &IgnoreTags{Keys: TagMap{"ignored": ""}, KeyPrefixes: TagMap{}}}
+
+	d := dataSourceAlksLtk().TestResourceData()
+	_ = d.Set("iam_username", "ci-deploy")
+	if diags := dataSourceAlksLtkRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Unexpected error: %#v", diags)
+	}
+
+	if arn := d.Get("iam_user_arn"); arn != "arn:aws:iam::012345678910:user/acct-managed/ci-deploy" {
+		t.Fatalf("Unexpected ARN %q", arn)
+	}
+	if key := d.Get("access_key_id"); key != "AKIA1" {
+		t.Fatalf("Unexpected access key ID %q", key)
+	}
+	if tags := d.Get("tags"); !reflect.DeepEqual(tags, map[string]interface{}{"team": "ci"}) {
+		t.Fatalf("Expected ignored tags to be dropped, got %v", tags)
+	}
+
+	d = dataSourceAlksLtk().TestResourceData()
+	_ = d.Set("iam_username", "missing")
+	if diags := dataSourceAlksLtkRead(context.Background(), d, meta); !diags.HasError() {
+		t.Fatal("Expected an error for a missing user")
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlksRoleTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlksRoleTypesRead,
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"role_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"trust_principals": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"trust_policy": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"supports_default_policies": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"instance_profile": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"template_fields": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAlksRoleTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ALKS Role Types Data Source Read")
+
+	providerStruct := meta.(*AlksClient)
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := traceAlksCall(ctx, client, "ListRoleTypes", func() (*listRoleTypesResponse, *alks.AlksError) {
+		return listRoleTypes(client)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := []string{}
+	roleTypes := []map[string]interface{}{}
+	for _, roleType := range resp.RoleTypes {
+		trustPolicy := ""
+		if roleType.TrustRelationship != nil {
+			jsonStrPolicy, err := json.Marshal(roleType.TrustRelationship)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			trustPolicy = string(jsonStrPolicy)
+		}
+
+		templateFields := roleType.TemplateFields
+		if templateFields == nil {
+			templateFields = []string{}
+		}
+
+		names = append(names, roleType.RoleTypeName)
+		roleTypes = append(roleTypes, map[string]interface{}{
+			"name":                      roleType.RoleTypeName,
+			"trust_principals":          trustPrincipals(roleType.TrustRelationship),
+			"trust_policy":              trustPolicy,
+			"supports_default_policies": len(roleType.DefaultArns) > 0,
+			"instance_profile":          roleType.InstanceProfile,
+			"template_fields":           templateFields,
+		})
+	}
+
+	d.SetId("alks_role_types")
+	_ = d.Set("names", names)
+	if err := d.Set("role_types", roleTypes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTrustPrincipals(t *testing.T) {
+	policy := map[string]interface{}{
+		"Statement": []interface{}{
+			map[string]interface{}{"Principal": map[string]interface{}{"Service": "ec2.amazonaws.com"}},
+			map[string]interface{}{"Principal": map[string]interface{}{"Service": []interface{}{"lambda.amazonaws.com", "edgelambda.amazonaws.com"}}},
+			map[string]interface{}{"Principal": map[string]interface{}{"Federated": "arn:aws:iam::012345678910:oidc-provider/oidc.eks"}},
+		},
+	}
+
+	expected := []string{"ec2.amazonaws.com", "lambda.amazonaws.com", "edgelambda.amazonaws.com", "arn:aws:iam::012345678910:oidc-provider/oidc.eks"}
+	if actual := trustPrincipals(policy); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected principals %v, got %v", expected, actual)
+	}
+
+	if actual := trustPrincipals(nil); len(actual) != 0 {
+		t.Fatalf("Expected no principals for a missing policy, got %v", actual)
+	}
+}
+
+func TestDataSourceAlksRoleTypesRead(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	fake.addRoleType(roleType{
+		RoleTypeName:    "Amazon EC2",
+		InstanceProfile: true,
+		DefaultArns:     []string{"arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore"},
+		TrustRelationship: map[string]interface{}{"Version": "2012-10-17", "Statement": []interface{}{
+			map[string]interface{}{"Effect": "Allow", "Principal": map[string]interface{}{"Service": "ec2.amazonaws.com"}, "Action": "sts:AssumeRole"},
+		}},
+	})
+	fake.addRoleType(roleType{
+		RoleTypeName:   "Amazon EKS IRSA",
+		TemplateFields: []string{"OIDC_PROVIDER", "K8S_NAMESPACE", "K8S_SERVICE_ACCOUNT"},
+		TrustRelationship: map[string]interface{}{"Version": "2012-10-17", "Statement": []interface{}{
+			map[string]interface{}{"Effect": "Allow", "Principal": map[string]interface{}{"Federated": "arn:aws:iam::012345678910:oidc-provider/{{OIDC_PROVIDER}}"}, "Action": "sts:AssumeRoleWithWebIdentity"},
+		}},
+	})
+
+	d := dataSourceAlksRoleTypes().TestResourceData()
+	if diags := dataSourceAlksRoleTypesRead(context.Background(), d, &AlksClient{client: fake.client(t)}); diags.HasError() {
+		t.Fatalf("Unexpected error: %#v", diags)
+	}
+
+	if names := d.Get("names"); !reflect.DeepEqual(names, []interface{}{"Amazon EC2", "Amazon EKS IRSA"}) {
+		t.Fatalf("Unexpected role type names %v", names)
+	}
+
+	ec2 := d.Get("role_types.0").(map[string]interface{})
+	if ec2["supports_default_policies"] != true || ec2["instance_profile"] != true || !reflect.DeepEqual(ec2["trust_principals"], []interface{}{"ec2.amazonaws.com"}) {
+		t.Fatalf("Unexpected EC2 role type %v", ec2)
+	}
+
+	irsa := d.Get("role_types.1").(map[string]interface{})
+	if irsa["supports_default_policies"] != false || irsa["instance_profile"] != false {
+		t.Fatalf("Unexpected IRSA role type %v", irsa)
+	}
+	if !reflect.DeepEqual(irsa["template_fields"], []interface{}{"OIDC_PROVIDER", "K8S_NAMESPACE", "K8S_SERVICE_ACCOUNT"}) {
+		t.Fatalf("Unexpected IRSA template fields %v", irsa["template_fields"])
+	}
+}
+
+func TestListRoleTypes_WireFormat(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	fake.override("GET", "/allAwsRoleTypes", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"roleTypes": [{"roleTypeName": "Amazon EC2", "defArns": ["arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore"], "insProfile": true, "trustRelationship": {}, "templateFields": []}]}`)
+	})
+
+	resp, err := listRoleTypes(fake.client(t))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []roleType{{
+		RoleTypeName:      "Amazon EC2",
+		TrustRelationship: map[string]interface{}{},
+		DefaultArns:       []string{"arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore"},
+		InstanceProfile:   true,
+		TemplateFields:    []string{},
+	}}
+	if !reflect.DeepEqual(resp.RoleTypes, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, resp.RoleTypes)
+	}
+}
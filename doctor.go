@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// doctorCommand is the argument that runs the provider binary as a checkup of
+// the provider configuration instead of a Terraform plugin.
+const doctorCommand = "doctor"
+
+// doctorStep is one step of configuring the provider. A step that returns a
+// doctorSkip did not apply to the configuration. When a required step fails,
+// the steps after it are not run.
+type doctorStep struct {
+	name     string
+	required bool
+	run      func(ctx context.Context) (string, error)
+}
+
+// doctorSkip is returned by a step that does not apply, with the reason why.
+type doctorSkip string
+
+func (s doctorSkip) Error() string {
+	return string(s)
+}
+
+// runDoctor configures the provider the same way configureProvider does,
+// reporting each step as it goes. It returns an error when any step failed.
+func runDoctor(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	config, verbose, err := commandConfig(doctorCommand, args, stderr)
+	if err != nil {
+		return err
+	}
+
+	if verbose {
+		log.SetOutput(stderr)
+	} else {
+		log.SetOutput(io.Discard)
+	}
+
+	if !runDoctorSteps(ctx, doctorSteps(config), stdout) {
+		return fmt.Errorf("The ALKS provider configuration has problems, see the failed steps above")
+	}
+
+	return nil
+}
+
+// doctorSteps breaks Config.Client into the steps the doctor reports on.
+func doctorSteps(config *Config) []doctorStep {
+	var creds *credentials.Credentials
+	var stsconn *sts.STS
+	var cp credentials.Value
+	var client *alks.Client
+
+	return []doctorStep{
+		{"Resolve credentials", true, func(ctx context.Context) (string, error) {
+			var err error
+			creds, err = config.baseCredentials(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			return fmt.Sprintf("from %s", config.credentialSource), nil
+		}},
+		{"Assume role", true, func(ctx context.Context) (string, error) {
+			var err error
+			stsconn, cp, err = config.stsConnection(ctx, creds)
+			if err != nil {
+				return "", err
+			}
+			if config.AssumeRole.RoleARN == "" {
+				return "", doctorSkip("no role to assume")
+			}
+
+			return config.AssumeRole.RoleARN, nil
+		}},
+		{"STS GetCallerIdentity", true, func(ctx context.Context) (string, error) {
+			if err := config.verifyCredentials(stsconn); err != nil {
+				return "", err
+			}
+
+			return aws.StringValue(config.baseIdentity.Arn), nil
+		}},
+		{"ALKS reachability", true, func(ctx context.Context) (string, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.URL, nil)
+			if err != nil {
+				return "", err
+			}
+			resp, err := alksHTTPClient.Do(req)
+			if err != nil {
+				return "", err
+			}
+			resp.Body.Close()
+
+			return fmt.Sprintf("%s responded %s", config.URL, resp.Status), nil
+		}},
+		{"ALKS GetMyLoginRole", true, func(ctx context.Context) (string, error) {
+			var err error
+			client, err = newTracedSTSClient(ctx, config.URL, cp.AccessKeyID, cp.SecretAccessKey, cp.SessionToken)
+			if err != nil {
+				return "", err
+			}
+			client.SetUserAgent(config.userAgent())
+
+			resp, loginErr := traceAlksCall(ctx, client, "GetMyLoginRole", client.GetMyLoginRole)
+			if loginErr != nil {
+				if urlErr := checkAlksURL(client); urlErr != nil {
+					return "", urlErr
+				}
+				return "", loginErr
+			}
+
+			return fmt.Sprintf("%s as %s", resp.LoginRole.Account, resp.LoginRole.Role), nil
+		}},
+		{"IAM-active check", false, func(ctx context.Context) (string, error) {
+			if err := validateIAMEnabled(ctx, client, callerRoleArn(config.baseIdentity)); err != nil {
+				return "", err
+			}
+
+			return "IAM active", nil
+		}},
+		{"Account switch", true, func(ctx context.Context) (string, error) {
+			if config.Account == "" || config.Role == "" {
+				return "", doctorSkip("no account and role to switch to")
+			}
+
+			accountClient, err := generateNewClient(ctx, config, client)
+			if err != nil {
+				return "", err
+			}
+
+			return accountClient.AccountDetails.Account, nil
+		}},
+	}
+}
+
+// runDoctorSteps runs steps in order, writing a line for each to w. It
+// returns whether every step that ran passed.
+func runDoctorSteps(ctx context.Context, steps []doctorStep, w io.Writer) bool {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+
+	ok := true
+	blocked := ""
+	for _, step := range steps {
+		if blocked != "" {
+			fmt.Fprintf(tw, "SKIP\t%s\t\tnot run, %s failed\n", step.name, blocked)
+			continue
+		}
+
+		start := time.Now()
+		detail, err := step.run(ctx)
+		elapsed := time.Since(start).Round(time.Millisecond)
+
+		switch err.(type) {
+		case nil:
+			fmt.Fprintf(tw, "PASS\t%s\t%s\t%s\n", step.name, elapsed, detail)
+		case doctorSkip:
+			fmt.Fprintf(tw, "SKIP\t%s\t%s\t%s\n", step.name, elapsed, err)
+		default:
+			fmt.Fprintf(tw, "FAIL\t%s\t%s\t%s\n", step.name, elapsed, err)
+			ok = false
+			if step.required {
+				blocked = step.name
+			}
+		}
+	}
+
+	return ok
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestRunDoctorSteps(t *testing.T) {
+	pass := func(context.Context) (string, error) { return "ok", nil }
+	var ran []string
+	record := func(name string, err error) func(context.Context) (string, error) {
+		return func(context.Context) (string, error) {
+			ran = append(ran, name)
+			return "", err
+		}
+	}
+
+	var out bytes.Buffer
+	ok := runDoctorSteps(context.Background(), []doctorStep{
+		{"first", true, pass},
+		{"skipped", true, record("skipped", doctorSkip("not configured"))},
+		{"advisory", false, record("advisory", errors.New("not IAM active"))},
+		{"required", true, record("required", errors.New("boom"))},
+		{"last", true, record("last", nil)},
+	}, &out)
+
+	if ok {
+		t.Fatalf("Expected failed steps to be reported")
+	}
+	if len(ran) != 3 || ran[2] != "required" {
+		t.Fatalf("Expected the steps after a failed required step not to run, ran %v", ran)
+	}
+
+	for _, line := range []string{
+		`PASS\s+first\s+\d+s?m?s\s+ok`,
+		`SKIP\s+skipped\s+.*not configured`,
+		`FAIL\s+advisory\s+.*not IAM active`,
+		`FAIL\s+required\s+.*boom`,
+		`SKIP\s+last\s+not run, required failed`,
+	} {
+		if !regexp.MustCompile(line).MatchString(out.String()) {
+			t.Errorf("Expected output to match %q, got:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestRunDoctor_NoCredentials(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	defer log.SetOutput(os.Stderr)
+
+	var out bytes.Buffer
+	err := runDoctor(context.Background(), []string{
+		"-url", "https://alks.example.com/rest",
+		"-shared-credentials-file", filepath.Join(t.TempDir(), "credentials"),
+	}, &out, &out)
+
+	if err == nil {
+		t.Fatalf("Expected an error without credentials")
+	}
+	if !regexp.MustCompile(`FAIL\s+Resolve credentials\s+.*No valid credential sources`).MatchString(out.String()) {
+		t.Fatalf("Expected the credential step to fail, got:\n%s", out.String())
+	}
+	if !regexp.MustCompile(`SKIP\s+Account switch\s+not run, Resolve credentials failed`).MatchString(out.String()) {
+		t.Fatalf("Expected the remaining steps not to run, got:\n%s", out.String())
+	}
+}
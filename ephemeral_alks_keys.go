@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ephemeralAlksKeys mints the same session as the alks_keys data source, but
+// Terraform never writes it to plan or state.
+type ephemeralAlksKeys struct {
+	meta *AlksClient
+}
+
+type ephemeralAlksKeysModel struct {
+	Account            types.String `tfsdk:"account"`
+	Role               types.String `tfsdk:"role"`
+	MachineIdentityArn types.String `tfsdk:"machine_identity_arn"`
+	DurationHours      types.Int64  `tfsdk:"duration_hours"`
+	UseIAM             types.Bool   `tfsdk:"use_iam"`
+	AccessKey          types.String `tfsdk:"access_key"`
+	SecretKey          types.String `tfsdk:"secret_key"`
+	SessionToken       types.String `tfsdk:"session_token"`
+	Expiration         types.String `tfsdk:"expiration"`
+	SessionDuration    types.Int64  `tfsdk:"session_duration"`
+	Profile            types.String `tfsdk:"profile"`
+	Env                types.Map    `tfsdk:"env"`
+	CredentialProcess  types.String `tfsdk:"credential_process"`
+	SharedCredentials  types.String `tfsdk:"shared_credentials"`
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralAlksKeys{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &ephemeralAlksKeys{}
+
+func newEphemeralAlksKeys() ephemeral.EphemeralResource {
+	return &ephemeralAlksKeys{}
+}
+
+func (e *ephemeralAlksKeys) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_keys"
+}
+
+func (e *ephemeralAlksKeys) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Session credentials minted by ALKS, which are never stored in plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"account": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The 12 digit account number to mint credentials for. Must be set with role.",
+			},
+			"role": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The role to mint credentials for. Must be set with account.",
+			},
+			"machine_identity_arn": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ARN of a machine identity to mint credentials for, in place of account and role.",
+			},
+			"duration_hours": schema.Int64Attribute{
+				Optional:    true,
+				Description: "How many hours the credentials are valid for. Defaults to 1.",
+			},
+			"use_iam": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to mint IAM session credentials rather than console credentials. Defaults to true.",
+			},
+			"access_key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"secret_key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"session_token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"expiration": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the credentials expire, in RFC 3339 format.",
+			},
+			"session_duration": schema.Int64Attribute{
+				Computed:    true,
+				Description: "How many hours ALKS issued the credentials for.",
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "The profile name used in shared_credentials. Defaults to default.",
+			},
+			"env": schema.MapAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "The credentials as AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables.",
+			},
+			"credential_process": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The credentials as version 1 credential_process JSON.",
+			},
+			"shared_credentials": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The credentials as a profile for the AWS shared credentials file.",
+			},
+		},
+	}
+}
+
+func (e *ephemeralAlksKeys) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	e.meta = req.ProviderData.(*AlksClient)
+}
+
+// ValidateConfig mirrors the constraints the data source declares in its schema.
+func (e *ephemeralAlksKeys) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var config ephemeralAlksKeysModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Account.IsNull() != config.Role.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("account"), "Invalid Attribute Combination", "account and role must be set together.")
+	}
+	if !config.Account.IsNull() && !config.Account.IsUnknown() {
+		_, errs := ValidAccountNumber(config.Account.ValueString(), "account")
+		for _, err := range errs {
+			resp.Diagnostics.AddAttributeError(path.Root("account"), "Invalid Attribute Value", err.Error())
+		}
+	}
+	if !config.MachineIdentityArn.IsNull() && (!config.Account.IsNull() || !config.Role.IsNull()) {
+		resp.Diagnostics.AddAttributeError(path.Root("machine_identity_arn"), "Invalid Attribute Combination", "machine_identity_arn conflicts with account and role.")
+	}
+	if !config.MachineIdentityArn.IsNull() && !config.MachineIdentityArn.IsUnknown() {
+		_, errs := ValidRoleArn(config.MachineIdentityArn.ValueString(), "machine_identity_arn")
+		for _, err := range errs {
+			resp.Diagnostics.AddAttributeError(path.Root("machine_identity_arn"), "Invalid Attribute Value", err.Error())
+		}
+	}
+	if !config.DurationHours.IsNull() && !config.DurationHours.IsUnknown() && config.DurationHours.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("duration_hours"), "Invalid Attribute Value", "duration_hours must be at least 1.")
+	}
+}
+
+func (e *ephemeralAlksKeys) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	log.Printf("[INFO] ALKS Keys Ephemeral Resource Open")
+	if e.meta == nil {
+		resp.Diagnostics.AddError("ALKS provider not configured", "The alks_keys ephemeral resource was opened before the ALKS provider was configured. Please report this issue to the provider developers.")
+		return
+	}
+	if diags := checkReadOnly(e.meta, "create ALKS session keys"); diags.HasError() {
+		resp.Diagnostics.AddError(diags[0].Summary, diags[0].Detail)
+		return
+	}
+
+	var config ephemeralAlksKeysModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys := keysRequest{
+		account:            config.Account.ValueString(),
+		role:               config.Role.ValueString(),
+		machineIdentityArn: config.MachineIdentityArn.ValueString(),
+		durationHours:      1,
+		useIAM:             true,
+	}
+	if !config.DurationHours.IsNull() {
+		keys.durationHours = int(config.DurationHours.ValueInt64())
+	}
+	if !config.UseIAM.IsNull() {
+		keys.useIAM = config.UseIAM.ValueBool()
+	}
+
+	session, client, err := e.meta.sessions.get(ctx, e.meta.client, keys)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating ALKS session keys", err.Error())
+		return
+	}
+
+	config.AccessKey = types.StringValue(session.AccessKey)
+	config.SecretKey = types.StringValue(session.SecretKey)
+	config.SessionToken = types.StringValue(session.SessionToken)
+	config.SessionDuration = types.Int64Value(int64(session.SessionDuration))
+	config.Expiration = types.StringNull()
+	if !session.Expires.IsZero() {
+		config.Expiration = types.StringValue(session.Expires.UTC().Format(time.RFC3339))
+	}
+
+	credentialProcess, err := sessionCredentialProcess(session)
+	if err != nil {
+		resp.Diagnostics.AddError("Error formatting ALKS session keys", err.Error())
+		return
+	}
+	env, diags := types.MapValueFrom(ctx, types.StringType, sessionEnv(session))
+	resp.Diagnostics.Append(diags...)
+	profile := "default"
+	if !config.Profile.IsNull() {
+		profile = config.Profile.ValueString()
+	}
+	config.Env = env
+	config.CredentialProcess = types.StringValue(credentialProcess)
+	config.SharedCredentials = types.StringValue(sessionSharedCredentials(profile, session))
+
+	if config.Account.IsNull() {
+		config.Account = types.StringValue(client.AccountDetails.Account)
+		config.Role = types.StringValue(strings.Split(client.AccountDetails.Role, "/")[0])
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
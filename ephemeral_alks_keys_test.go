@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProviderServer_schemas(t *testing.T) {
+	for _, url := range []string{"", "https://alks.example.com/rest"} {
+		t.Setenv("ALKS_URL", url)
+
+		server, err := newProviderServer(context.Background())
+		if err != nil {
+			t.Fatalf("Error creating provider server: %s", err)
+		}
+
+		resp, err := server().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			t.Fatalf("Error getting provider schema: %s", err)
+		}
+		for _, d := range resp.Diagnostics {
+			t.Errorf("ALKS_URL=%q: unexpected diagnostic: %s: %s", url, d.Summary, d.Detail)
+		}
+		if _, ok := resp.EphemeralResourceSchemas["alks_keys"]; !ok {
+			t.Fatalf("Expected an alks_keys ephemeral resource, got %v", resp.EphemeralResourceSchemas)
+		}
+	}
+}
+
+func TestEphemeralAlksKeysOpen(t *testing.T) {
+	ctx := context.Background()
+	fake := newTestFakeAlks(t)
+	e := &ephemeralAlksKeys{meta: &AlksClient{client: fake.client(t)}}
+
+	var schemaResp ephemeral.SchemaResponse
+	e.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["account"] = tftypes.NewValue(tftypes.String, "109876543210")
+	values["role"] = tftypes.NewValue(tftypes.String, "LabAdmin")
+
+	req := ephemeral.OpenRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}
+	resp := ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+
+	var validateResp ephemeral.ValidateConfigResponse
+	e.ValidateConfig(ctx, ephemeral.ValidateConfigRequest{Config: req.Config}, &validateResp)
+	if validateResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected validation error: %v", validateResp.Diagnostics)
+	}
+
+	e.Open(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+	}
+
+	var result ephemeralAlksKeysModel
+	resp.Result.Get(ctx, &result)
+
+	if session, _ := fake.session(result.AccessKey.ValueString()); session.Account != "109876543210/ALKSLabAdmin" || !session.iam {
+		t.Fatalf("Expected IAM keys for 109876543210/ALKSLabAdmin, got %+v", session)
+	}
+	if result.SessionDuration.ValueInt64() != 1 || result.Expiration.IsNull() {
+		t.Fatalf("Expected a one hour session with an expiration, got %d hours expiring %q", result.SessionDuration.ValueInt64(), result.Expiration.ValueString())
+	}
+}
+
+func TestEphemeralAlksKeysOpen_NotConfigured(t *testing.T) {
+	ctx := context.Background()
+	e := &ephemeralAlksKeys{}
+	e.Configure(ctx, ephemeral.ConfigureRequest{}, &ephemeral.ConfigureResponse{})
+
+	var schemaResp ephemeral.SchemaResponse
+	e.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	var resp ephemeral.OpenResponse
+	e.Open(ctx, ephemeral.OpenRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}, &resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "ALKS provider not configured" {
+		t.Fatalf("Expected a provider not configured error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestEphemeralAlksKeysValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	e := &ephemeralAlksKeys{}
+
+	var schemaResp ephemeral.SchemaResponse
+	e.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	cases := []struct {
+		name   string
+		config map[string]tftypes.Value
+		valid  bool
+	}{
+		{"empty", nil, true},
+		{"account without role", map[string]tftypes.Value{"account": tftypes.NewValue(tftypes.String, "109876543210")}, false},
+		{"short account", map[string]tftypes.Value{
+			"account": tftypes.NewValue(tftypes.String, "123"),
+			"role":    tftypes.NewValue(tftypes.String, "Dev"),
+		}, false},
+		{"machine identity and account", map[string]tftypes.Value{
+			"machine_identity_arn": tftypes.NewValue(tftypes.String, "arn:aws:iam::109876543210:role/acct-managed/my-mi"),
+			"account":              tftypes.NewValue(tftypes.String, "109876543210"),
+			"role":                 tftypes.NewValue(tftypes.String, "LabAdmin"),
+		}, false},
+		{"bad machine identity", map[string]tftypes.Value{"machine_identity_arn": tftypes.NewValue(tftypes.String, "my-mi")}, false},
+		{"zero duration", map[string]tftypes.Value{"duration_hours": tftypes.NewValue(tftypes.Number, 0)}, false},
+	}
+
+	for _, c := range cases {
+		values := map[string]tftypes.Value{}
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		for name, v := range c.config {
+			values[name] = v
+		}
+
+		var resp ephemeral.ValidateConfigResponse
+		e.ValidateConfig(ctx, ephemeral.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}, &resp)
+
+		if resp.Diagnostics.HasError() == c.valid {
+			t.Errorf("%s: expected valid=%t, got %v", c.name, c.valid, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,744 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const (
+	fakeAlksAccount   = "012345678910"
+	fakeAlksRole      = "Admin"
+	fakeAlksAccessKey = "ASIAFAKEALKS"
+)
+
+// fakeAlks is an in-memory ALKS for running the provider without network. The
+// ALKS API is served under /rest and STS GetCallerIdentity at the root, so the
+// same server can back both the provider's url and its STS endpoint.
+type fakeAlks struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	mux            *http.ServeMux
+	overrides      map[string]http.HandlerFunc
+	iamActive      bool
+	miIamActive    bool
+	maxKeyDuration int
+	requests       int
+	accounts       map[string][]alks.AccountRole
+	roleTypes      []roleType
+	roles          map[string]*alks.GetIamRoleResponse
+	users          map[string]*fakeUser
+	// sessions maps the access key of every session handed out to the session,
+	// so clients switched to another account see its login role
+	sessions map[string]fakeSession
+}
+
+// fakeSession is a session the fake handed out.
+type fakeSession struct {
+	alks.LoginRole
+	iam bool
+}
+
+// fakeUser is an LTK user, along with what ALKS lists about its key.
+type fakeUser struct {
+	alks.IamUser
+	status     string
+	createDate string
+}
+
+func newFakeAlks() *fakeAlks {
+	f := &fakeAlks{
+		overrides:      map[string]http.HandlerFunc{},
+		iamActive:      true,
+		miIamActive:    true,
+		maxKeyDuration: 12,
+		accounts:       map[string][]alks.AccountRole{},
+		roles:          map[string]*alks.GetIamRoleResponse{},
+		users:          map[string]*fakeUser{},
+		sessions:       map[string]fakeSession{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /{$}", f.getCallerIdentity)
+	mux.HandleFunc("GET /rest/loginRoles/id/me", f.getMyLoginRole)
+	mux.HandleFunc("GET /rest/loginRoles/id/{account}/{role}", f.getLoginRole)
+	mux.HandleFunc("POST /rest/getAccounts/{$}", f.getAccounts)
+	mux.HandleFunc("POST /rest/isIamEnabled", f.isIamEnabled)
+	mux.HandleFunc("POST /rest/getKeys/{$}", f.createSession(false))
+	mux.HandleFunc("POST /rest/getIAMKeys/{$}", f.createSession(true))
+	mux.HandleFunc("POST /rest/createRole/{$}", f.createRole)
+	mux.HandleFunc("POST /rest/createNonServiceRole/{$}", f.createRole)
+	mux.HandleFunc("PATCH /rest/role/{$}", f.updateRole)
+	mux.HandleFunc("POST /rest/deleteRole/{$}", f.deleteRole)
+	mux.HandleFunc("POST /rest/getAccountRole/{$}", f.getRole)
+	mux.HandleFunc("POST /rest/roleMachineIdentity/{$}", f.setMachineIdentity(true))
+	mux.HandleFunc("DELETE /rest/roleMachineIdentity/{$}", f.setMachineIdentity(false))
+	mux.HandleFunc("POST /rest/roleMachineIdentity/search/{$}", f.searchMachineIdentity)
+	mux.HandleFunc("GET /rest/ltks/{account}/{role}", f.listUsers)
+	mux.HandleFunc("GET /rest/iam-users/id/{account}/{name}", f.getUser)
+	mux.HandleFunc("PATCH /rest/iam-users/id/{account}/{name}", f.updateUser)
+	mux.HandleFunc("POST /rest/accessKeys", f.createUser)
+	mux.HandleFunc("DELETE /rest/IAMUser", f.deleteUser)
+	mux.HandleFunc("GET /rest/allAwsRoleTypes", f.listRoleTypes)
+	f.mux = mux
+
+	f.Server = httptest.NewServer(f)
+
+	return f
+}
+
+// ServeHTTP answers a request with its override, if it has one, or the fake's
+// ALKS and STS otherwise.
+func (f *fakeAlks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	override, ok := f.overrides[r.Method+" "+r.URL.Path]
+	f.mu.Unlock()
+
+	if ok {
+		override(w, r)
+		return
+	}
+	f.mux.ServeHTTP(w, r)
+}
+
+// override answers method and path, under the ALKS API, with h instead of
+// the fake, such as to make an endpoint fail.
+func (f *fakeAlks) override(method string, path string, h http.HandlerFunc) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.overrides[method+" "+alksAPIPath+path] = h
+}
+
+// client returns an ALKS client for the fake's login role.
+func (f *fakeAlks) client(t *testing.T) *alks.Client {
+	t.Helper()
+
+	client, err := alks.NewSTSClient(f.alksURL(), fakeAlksAccessKey, "secret", "token")
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	return client
+}
+
+// newTestFakeAlks starts a fakeAlks that is closed when t finishes.
+func newTestFakeAlks(t *testing.T) *fakeAlks {
+	f := newFakeAlks()
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+// configure configures the provider against the fake, with raw as the rest of
+// the provider block, and returns its meta.
+func (f *fakeAlks) configure(t *testing.T, raw map[string]interface{}) *AlksClient {
+	t.Helper()
+
+	meta, _ := f.configureDiags(t, raw)
+
+	return meta
+}
+
+// configureDiags is configure, also returning the warnings the provider gave.
+func (f *fakeAlks) configureDiags(t *testing.T, raw map[string]interface{}) (*AlksClient, diag.Diagnostics) {
+	t.Helper()
+
+	config := map[string]interface{}{
+		"url":        f.alksURL(),
+		"access_key": fakeAlksAccessKey,
+		"secret_key": "secret",
+		"token":      "token",
+	}
+	for k, v := range raw {
+		config[k] = v
+	}
+
+	p := Provider()
+	p.ConfigureContextFunc = configureProvider(f.URL)
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+	if diags.HasError() {
+		t.Fatalf("Error configuring the provider: %v", diags)
+	}
+
+	return p.Meta().(*AlksClient), diags
+}
+
+// fakeApply plans raw against state the way Terraform would and applies the
+// plan, then checks that planning raw again finds nothing to change.
+func fakeApply(t *testing.T, r *schema.Resource, meta *AlksClient, state *terraform.InstanceState, raw map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+	ctx := context.Background()
+	config := terraform.NewResourceConfigRaw(raw)
+
+	diff, err := r.Diff(ctx, state, config, meta)
+	if err != nil {
+		t.Fatalf("Error planning: %s", err)
+	}
+	state, diags := r.Apply(ctx, state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("Error applying: %v", diags)
+	}
+
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, meta)
+	if diags.HasError() {
+		t.Fatalf("Error refreshing: %v", diags)
+	}
+	if diff, err := r.Diff(ctx, state, config, meta); err != nil || !diff.Empty() {
+		t.Fatalf("Expected an empty plan after applying, got %v (%v)", diff, err)
+	}
+
+	return state
+}
+
+// fakeDestroy destroys the resource in state.
+func fakeDestroy(t *testing.T, r *schema.Resource, meta *AlksClient, state *terraform.InstanceState) {
+	t.Helper()
+
+	if _, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, meta); diags.HasError() {
+		t.Fatalf("Error destroying: %v", diags)
+	}
+}
+
+// alksURL is the base URL of the fake's ALKS API.
+func (f *fakeAlks) alksURL() string {
+	return f.URL + alksAPIPath
+}
+
+// setIamActive sets whether the fake's login role is IAM active.
+func (f *fakeAlks) setIamActive(active bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.iamActive = active
+}
+
+// setMachineIdentitiesIamActive sets whether the fake's machine identities are
+// IAM active.
+func (f *fakeAlks) setMachineIdentitiesIamActive(active bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.miIamActive = active
+}
+
+// setMaxKeyDuration sets the longest session, in hours, the fake hands out.
+func (f *fakeAlks) setMaxKeyDuration(hours int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.maxKeyDuration = hours
+}
+
+// addAccount adds an account and role the caller has in ALKS, besides its
+// login role.
+func (f *fakeAlks) addAccount(account string, role alks.AccountRole) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.accounts[account] = append(f.accounts[account], role)
+}
+
+// addRoleType adds a role type to the fake's catalog.
+func (f *fakeAlks) addRoleType(roleType roleType) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.roleTypes = append(f.roleTypes, roleType)
+}
+
+// session returns the session the fake handed out with accessKey.
+func (f *fakeAlks) session(accessKey string) (fakeSession, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	session, ok := f.sessions[accessKey]
+
+	return session, ok
+}
+
+// loginRole returns the login role the credentials of r belong to. Callers
+// must hold f.mu.
+func (f *fakeAlks) loginRole(r *http.Request) alks.LoginRole {
+	if session, ok := f.sessions[r.Header.Get("ALKS-STS-Access-Key")]; ok {
+		return session.LoginRole
+	}
+
+	return alks.LoginRole{
+		Account:        fakeAlksAccount + "/ALKS" + fakeAlksRole,
+		Role:           fakeAlksRole,
+		IamKeyActive:   f.iamActive,
+		MaxKeyDuration: f.maxKeyDuration,
+	}
+}
+
+// reply writes v as the JSON response to a request. Callers must hold f.mu.
+func (f *fakeAlks) reply(w http.ResponseWriter, status int, v interface{}) {
+	f.requests++
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-ID", fmt.Sprintf("fake-%d", f.requests))
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// fail writes an ALKS error response. Callers must hold f.mu.
+func (f *fakeAlks) fail(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	f.reply(w, status, alks.AlksResponseError{Errors: []string{fmt.Sprintf(format, args...)}})
+}
+
+// decode reads the JSON body of r into v, failing the request when it can't.
+// Callers must hold f.mu.
+func (f *fakeAlks) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		f.fail(w, http.StatusBadRequest, "Invalid request body: %s", err)
+		return false
+	}
+
+	return true
+}
+
+func (f *fakeAlks) getCallerIdentity(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("Action") != "GetCallerIdentity" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// answer for whichever session signed the request, going by the access key
+	// in the SigV4 credential scope
+	f.mu.Lock()
+	session, ok := f.sessions[sigV4AccessKey(r)]
+	role := session.LoginRole
+	f.mu.Unlock()
+	if !ok {
+		role = alks.LoginRole{Account: fakeAlksAccount, Role: fakeAlksRole}
+	}
+
+	w.Header().Set("Content-Type", "text/xml")
+	w.Header().Set("X-Amzn-Requestid", "fake-sts")
+	fmt.Fprintf(w, `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>arn:aws:sts::%[1]s:assumed-role/%[2]s/fake</Arn>
+    <UserId>AROAFAKEALKS:fake</UserId>
+    <Account>%[1]s</Account>
+  </GetCallerIdentityResult>
+  <ResponseMetadata>
+    <RequestId>fake-sts</RequestId>
+  </ResponseMetadata>
+</GetCallerIdentityResponse>`, accountNumber(role.Account), role.Role)
+}
+
+// sigV4AccessKey returns the access key a request was signed with.
+func sigV4AccessKey(r *http.Request) string {
+	_, credential, _ := strings.Cut(r.Header.Get("Authorization"), "Credential=")
+	accessKey, _, _ := strings.Cut(credential, "/")
+
+	return accessKey
+}
+
+func (f *fakeAlks) getMyLoginRole(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reply(w, http.StatusOK, alks.LoginRoleResponse{LoginRole: f.loginRole(r)})
+}
+
+func (f *fakeAlks) getLoginRole(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reply(w, http.StatusOK, alks.LoginRoleResponse{LoginRole: alks.LoginRole{
+		Account:        r.PathValue("account") + "/ALKS" + r.PathValue("role"),
+		Role:           r.PathValue("role"),
+		IamKeyActive:   f.iamActive,
+		MaxKeyDuration: f.maxKeyDuration,
+	}})
+}
+
+func (f *fakeAlks) getAccounts(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	role := f.loginRole(r)
+	accounts := map[string][]alks.AccountRole{
+		role.Account: {{Role: role.Role, IamActive: role.IamKeyActive}},
+	}
+	for account, roles := range f.accounts {
+		accounts[account] = append(accounts[account], roles...)
+	}
+
+	f.reply(w, http.StatusOK, alks.AccountsResponseInt{Accounts: accounts})
+}
+
+func (f *fakeAlks) isIamEnabled(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req alks.IsIamEnabledRequest
+	if !f.decode(w, r, &req) {
+		return
+	}
+
+	// answer with the account and role a machine identity resolves to, and
+	// with the caller's own account details for anything else
+	details := req.AccountDetails
+	iamEnabled := f.iamActive
+	if req.RoleArn != "" {
+		role := f.roleByArn(req.RoleArn)
+		iamEnabled = role != nil && role.AlksAccess && f.miIamActive
+		if role != nil && role.AlksAccess {
+			details = alks.AccountDetails{
+				Account: strings.Split(role.RoleArn, ":")[4] + "/ALKS" + role.RoleName,
+				Role:    role.RoleName,
+			}
+		}
+	}
+
+	f.reply(w, http.StatusOK, alks.IsIamEnabledResponse{
+		AccountDetails: details,
+		RoleArn:        req.RoleArn,
+		IamEnabled:     iamEnabled,
+	})
+}
+
+func (f *fakeAlks) createSession(iam bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		var req struct {
+			alks.SessionRequest
+			alks.AccountDetails
+		}
+		if !f.decode(w, r, &req) {
+			return
+		}
+
+		if req.SessionDuration < 1 || req.SessionDuration > f.maxKeyDuration {
+			f.fail(w, http.StatusBadRequest, "Unsupported session duration: %d", req.SessionDuration)
+			return
+		}
+
+		accessKey := fmt.Sprintf("%s%d", fakeAlksAccessKey, len(f.sessions)+1)
+		f.sessions[accessKey] = fakeSession{
+			LoginRole: alks.LoginRole{
+				Account:        req.Account,
+				Role:           req.Role,
+				IamKeyActive:   f.iamActive,
+				MaxKeyDuration: f.maxKeyDuration,
+			},
+			iam: iam,
+		}
+
+		f.reply(w, http.StatusOK, alks.SessionResponse{
+			AccessKey:       accessKey,
+			SecretKey:       "secret",
+			SessionToken:    "token",
+			SessionDuration: req.SessionDuration,
+			Expires:         time.Now().Add(time.Duration(req.SessionDuration) * time.Hour),
+		})
+	}
+}
+
+func (f *fakeAlks) createRole(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req alks.IamRoleRequest
+	if !f.decode(w, r, &req) {
+		return
+	}
+	if _, ok := f.roles[req.RoleName]; ok {
+		f.fail(w, http.StatusBadRequest, "Role already exists with the same name: %s", req.RoleName)
+		return
+	}
+
+	role := &alks.GetIamRoleResponse{
+		RoleName:                    req.RoleName,
+		RoleType:                    req.RoleType,
+		TrustPolicy:                 req.TrustPolicy,
+		RoleArn:                     fmt.Sprintf("arn:aws:iam::%s:role/acct-managed/%s", fakeAlksAccount, req.RoleName),
+		Exists:                      true,
+		AlksAccess:                  req.AlksAccess,
+		Tags:                        req.Tags,
+		MaxSessionDurationInSeconds: req.MaxSessionDurationInSeconds,
+	}
+	if role.TrustPolicy == nil {
+		role.TrustPolicy = map[string]interface{}{"Version": "2012-10-17", "Statement": []interface{}{}}
+	}
+	if req.RoleType == "Amazon EC2" {
+		role.RoleIPArn = fmt.Sprintf("arn:aws:iam::%s:instance-profile/acct-managed/%s", fakeAlksAccount, req.RoleName)
+		role.RoleAddedToIP = true
+	}
+	f.roles[req.RoleName] = role
+
+	f.reply(w, http.StatusOK, alks.IamRoleResponse{
+		RoleName:                    role.RoleName,
+		RoleType:                    role.RoleType,
+		TrustPolicy:                 role.TrustPolicy,
+		RoleArn:                     role.RoleArn,
+		RoleIPArn:                   role.RoleIPArn,
+		RoleAddedToIP:               role.RoleAddedToIP,
+		Exists:                      true,
+		TemplateFields:              req.TemplateFields,
+		MaxSessionDurationInSeconds: role.MaxSessionDurationInSeconds,
+	})
+}
+
+func (f *fakeAlks) updateRole(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req alks.UpdateIamRoleRequest
+	if !f.decode(w, r, &req) {
+		return
+	}
+	if req.RoleName == nil {
+		f.fail(w, http.StatusBadRequest, "roleName is required")
+		return
+	}
+	role, ok := f.roles[*req.RoleName]
+	if !ok {
+		f.fail(w, http.StatusNotFound, "Role not found: %s", *req.RoleName)
+		return
+	}
+
+	if req.Tags != nil {
+		role.Tags = *req.Tags
+	}
+	if req.TrustPolicy != nil {
+		role.TrustPolicy = *req.TrustPolicy
+	}
+
+	f.reply(w, http.StatusOK, alks.UpdateIamRoleResponse{
+		RoleArn:         &role.RoleArn,
+		RoleName:        &role.RoleName,
+		Exists:          &role.Exists,
+		RoleIPArn:       &role.RoleIPArn,
+		MachineIdentity: &role.AlksAccess,
+		Tags:            &role.Tags,
+	})
+}
+
+func (f *fakeAlks) deleteRole(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req alks.DeleteRoleRequest
+	if !f.decode(w, r, &req) {
+		return
+	}
+	if _, ok := f.roles[req.RoleName]; !ok {
+		f.fail(w, http.StatusNotFound, "Role not found: %s", req.RoleName)
+		return
+	}
+	delete(f.roles, req.RoleName)
+
+	f.reply(w, http.StatusOK, alks.DeleteRoleResponse{RoleName: req.RoleName})
+}
+
+func (f *fakeAlks) getRole(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req alks.GetRoleRequest
+	if !f.decode(w, r, &req) {
+		return
+	}
+	role, ok := f.roles[req.RoleName]
+	if !ok {
+		f.fail(w, http.StatusNotFound, "Role not found: %s", req.RoleName)
+		return
+	}
+
+	f.reply(w, http.StatusOK, role)
+}
+
+// roleByArn returns the role with arn, which may also be the STS ARN of a
+// session of the role. Callers must hold f.mu.
+func (f *fakeAlks) roleByArn(arn string) *alks.GetIamRoleResponse {
+	// arn:aws:sts::012345678910:assumed-role/my-mi/session
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) == 6 && parts[2] == "sts" {
+		resource := strings.Split(parts[5], "/")
+		if len(resource) != 3 || resource[0] != "assumed-role" {
+			return nil
+		}
+		for _, role := range f.roles {
+			if role.RoleName == resource[1] && strings.Split(role.RoleArn, ":")[4] == parts[4] {
+				return role
+			}
+		}
+
+		return nil
+	}
+
+	for _, role := range f.roles {
+		if role.RoleArn == arn {
+			return role
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeAlks) setMachineIdentity(enabled bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		var req alks.AddRoleMachineIdentityRequest
+		if !f.decode(w, r, &req) {
+			return
+		}
+		role := f.roleByArn(req.RoleARN)
+		if role == nil {
+			f.fail(w, http.StatusNotFound, "Role not found: %s", req.RoleARN)
+			return
+		}
+		role.AlksAccess = enabled
+
+		f.reply(w, http.StatusOK, alks.MachineIdentityResponse{MachineIdentityArn: role.RoleArn})
+	}
+}
+
+func (f *fakeAlks) searchMachineIdentity(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req alks.SearchRoleMachineIdentityRequest
+	if !f.decode(w, r, &req) {
+		return
+	}
+	role := f.roleByArn(req.RoleARN)
+	if role == nil || !role.AlksAccess {
+		f.fail(w, http.StatusNotFound, "Machine identity not found: %s", req.RoleARN)
+		return
+	}
+
+	f.reply(w, http.StatusOK, alks.MachineIdentityResponse{MachineIdentityArn: role.RoleArn})
+}
+
+func (f *fakeAlks) listUsers(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := alks.GetIamUsersResponse{IamUsers: []alks.AllIamUsersResponseType{}}
+	for _, user := range f.users {
+		resp.IamUsers = append(resp.IamUsers, alks.AllIamUsersResponseType{
+			UserName:    user.UserName,
+			AccessKeyID: user.AccessKey,
+			Status:      user.status,
+			CreateDate:  user.createDate,
+		})
+	}
+	sort.Slice(resp.IamUsers, func(i, j int) bool {
+		return resp.IamUsers[i].UserName < resp.IamUsers[j].UserName
+	})
+
+	f.reply(w, http.StatusOK, resp)
+}
+
+func (f *fakeAlks) getUser(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	user, ok := f.users[r.PathValue("name")]
+	if !ok {
+		f.fail(w, http.StatusNotFound, "IAM user not found: %s", r.PathValue("name"))
+		return
+	}
+
+	f.reply(w, http.StatusOK, alks.GetIamUserResponse{User: user.IamUser})
+}
+
+func (f *fakeAlks) updateUser(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req alks.UpdateIamUserRequest
+	if !f.decode(w, r, &req) {
+		return
+	}
+	user, ok := f.users[r.PathValue("name")]
+	if !ok {
+		f.fail(w, http.StatusNotFound, "IAM user not found: %s", r.PathValue("name"))
+		return
+	}
+	user.Tags = req.User.Tags
+
+	f.reply(w, http.StatusOK, alks.UpdateIamUserResponse{User: user.IamUser})
+}
+
+func (f *fakeAlks) createUser(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req alks.CreateIamUserRequest
+	if !f.decode(w, r, &req) {
+		return
+	}
+	if _, ok := f.users[req.IamUserName]; ok {
+		f.fail(w, http.StatusBadRequest, "IAM user already exists: %s", req.IamUserName)
+		return
+	}
+
+	user := &fakeUser{
+		IamUser: alks.IamUser{
+			ARN:       fmt.Sprintf("arn:aws:iam::%s:user/acct-managed/%s", fakeAlksAccount, req.IamUserName),
+			AccountId: fakeAlksAccount,
+			UserName:  req.IamUserName,
+			AccessKey: "AKIA" + strings.ToUpper(req.IamUserName),
+			Tags:      req.Tags,
+		},
+		status:     "Active",
+		createDate: time.Now().UTC().Format(time.RFC3339),
+	}
+	f.users[req.IamUserName] = user
+
+	f.reply(w, http.StatusOK, alks.CreateIamUserResponse{
+		AccountDetails: req.AccountDetails,
+		CreateIamUserApiResponse: alks.CreateIamUserApiResponse{
+			IAMUserName: user.UserName,
+			IAMUserArn:  user.ARN,
+			AccessKey:   user.AccessKey,
+			SecretKey:   "secret",
+		},
+	})
+}
+
+func (f *fakeAlks) deleteUser(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req alks.DeleteIamUserRequest
+	if !f.decode(w, r, &req) {
+		return
+	}
+	if _, ok := f.users[req.IamUserName]; !ok {
+		f.fail(w, http.StatusNotFound, "IAM user not found: %s", req.IamUserName)
+		return
+	}
+	delete(f.users, req.IamUserName)
+
+	f.reply(w, http.StatusOK, alks.DeleteIamUserResponse{AccountDetails: req.AccountDetails})
+}
+
+func (f *fakeAlks) listRoleTypes(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reply(w, http.StatusOK, map[string]interface{}{"roleTypes": f.roleTypes})
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/metaschema"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// newProviderServer serves the SDK provider alongside frameworkProvider,
+// which only exists for the features the SDK can't provide, such as ephemeral
+// resources.
+func newProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	primary := Provider()
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		primary.GRPCProvider,
+		providerserver.NewProtocol5(&frameworkProvider{primary: primary}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProvider shares the SDK provider's configuration. Terraform requires
+// both to declare the same provider schema, so changes to the schema in
+// Provider must be mirrored here.
+type frameworkProvider struct {
+	primary *sdkschema.Provider
+}
+
+var _ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+var _ provider.ProviderWithMetaSchema = &frameworkProvider{}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "alks"
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	// The SDK makes url optional when ALKS_URL supplies it
+	urlFromEnv := os.Getenv("ALKS_URL") != ""
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Required:    !urlFromEnv,
+				Optional:    urlFromEnv,
+				Description: "This is the base URL to ALKS service. It must be provided, but it can also be sourced from the ALKS_URL environment variable.",
+			},
+			"access_key": schema.StringAttribute{
+				Optional:    true,
+				Description: "This is the AWS access key. It must be provided, but it can also be sourced from the ALKS_ACCESS_KEY_ID or AWS_ACCESS_KEY_ID environment variable.",
+			},
+			"secret_key": schema.StringAttribute{
+				Optional:    true,
+				Description: "This is the AWS secret key. It must be provided, but it can also be sourced from the ALKS_SECRET_ACCESS_KEY or AWS_SECRET_ACCESS_KEY environment variable",
+			},
+			"token": schema.StringAttribute{
+				Optional:    true,
+				Description: "This is the AWS session token. It must be provided, but it can also be sourced from the ALKS_SESSION_TOKEN or AWS_SESSION_TOKEN environment variable",
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "The profile for API operations. Used in place of STS tokens.",
+			},
+			"shared_credentials_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path to the shared credentials file. If not set this defaults to ~/.aws/credentials.",
+			},
+			"account": schema.StringAttribute{
+				Optional:    true,
+				Description: "The account which you'd like to retrieve credentials for.",
+			},
+			"role": schema.StringAttribute{
+				Optional:    true,
+				Description: "The role which you'd like to retrieve credentials for.",
+			},
+			"audit_log_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a local file to append a JSON line to for every create, update or delete call made to ALKS. It can also be sourced from the ALKS_AUDIT_LOG_FILE environment variable.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, every create, update and delete of a resource, and minting keys with alks_keys, fails before calling ALKS. Useful for drift detection plans.",
+			},
+			"user_agent": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Product tokens (for example \"my-pipeline/1.2.0\") appended to the user agent of every call made to ALKS.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"assume_role": schema.SetNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"role_arn": schema.StringAttribute{
+							Optional:    true,
+							Description: "(Required) Role ARN to assume before calling ALKS",
+						},
+						"session_name": schema.StringAttribute{
+							Optional:    true,
+							Description: "(Optional) Session name to use when making the AssumeRole call.  See AWS SDK for more details.",
+						},
+						"external_id": schema.StringAttribute{
+							Optional:    true,
+							Description: "(Optional) The external ID to use when making the AssumeRole call. See AWS SDK for more details.",
+						},
+						"policy": schema.StringAttribute{
+							Optional:    true,
+							Description: "(Optional) Additional policy restrictions to apply to the result STS session.  See AWS SDK for more details.",
+						},
+					},
+				},
+			},
+			"default_tags": schema.ListNestedBlock{
+				Description: "Configuration block with settings to default resource tags across all resources.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"tags": schema.MapAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Description: "Resource tags to default across all resources",
+						},
+					},
+				},
+			},
+			"ignore_tags": schema.ListNestedBlock{
+				Description: "Configuration block with settings to ignore resource tags across all resources.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"keys": schema.SetAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Description: "Resource tag keys to ignore across all resources.",
+						},
+						"key_prefixes": schema.SetAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Description: "Resource tag key prefixes to ignore across all resources.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) MetaSchema(_ context.Context, _ provider.MetaSchemaRequest, resp *provider.MetaSchemaResponse) {
+	resp.Schema = metaschema.Schema{
+		Attributes: map[string]metaschema.Attribute{
+			"module_name": metaschema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the module using the provider, appended to the user agent sent to ALKS.",
+			},
+			"module_version": metaschema.StringAttribute{
+				Optional:    true,
+				Description: "Version of the module using the provider, appended to the user agent sent to ALKS.",
+			},
+		},
+	}
+}
+
+// Configure hands the SDK provider's client to framework resources. Terraform
+// configures the SDK provider first, as it is the first server muxed.
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	meta, ok := p.primary.Meta().(*AlksClient)
+	if !ok {
+		resp.Diagnostics.AddError("ALKS provider not configured", "The ALKS client was not created before ephemeral resources were configured.")
+		return
+	}
+
+	resp.EphemeralResourceData = meta
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newEphemeralAlksKeys,
+	}
+}
@@ -1,25 +1,195 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/Cox-Automotive/alks-go"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func validateIAMEnabled(client *alks.Client) *alks.AlksError {
+// checkReadOnly returns an error diagnostic when the provider is configured
+// with read_only, so callers can stop before making any mutating ALKS call.
+func checkReadOnly(meta interface{}, action string) diag.Diagnostics {
+	if !meta.(*AlksClient).readOnly {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Cannot %s: the ALKS provider is read-only", action),
+			Detail: "This provider is configured with read_only = true, which blocks every call that would change ALKS or mint credentials. " +
+				"Remove read_only from the provider block to make this change.",
+		},
+	}
+}
+
+// validateIAMEnabled checks that client can manage IAM resources. When
+// callerRoleArn is set, client uses the caller's own credentials and the
+// caller is that assumed role, such as a machine identity, which ALKS is asked
+// about directly when the login role isn't IAM active.
+func validateIAMEnabled(ctx context.Context, client *alks.Client, callerRoleArn string) *alks.AlksError {
 	// Validate STS for IAM active.
-	resp, err := client.GetMyLoginRole()
+	resp, err := traceAlksCall(ctx, client, "GetMyLoginRole", client.GetMyLoginRole)
 	if err != nil {
 		return err
 	}
 
-	if !resp.LoginRole.IamKeyActive {
-		return &alks.AlksError{
-			StatusCode: 0,
-			RequestId:  "",
-			Err: fmt.Errorf("uh oh! You're using the " + resp.LoginRole.Role + " role which is not IAM active. " +
-				"Please instead use one of the following roles: Admin, IAMAdmin, LabAdmin, or a Machine Identity"),
+	if resp.LoginRole.IamKeyActive {
+		return nil
+	}
+
+	if callerRoleArn != "" {
+		iamResp, err := traceAlksCall(ctx, client, "IsIamEnabled", func() (*alks.IsIamEnabledResponse, *alks.AlksError) {
+			return client.IsIamEnabled(callerRoleArn)
+		})
+		if err != nil {
+			return err
+		}
+		if iamResp.IamEnabled {
+			return nil
+		}
+	}
+
+	return &alks.AlksError{
+		StatusCode: 0,
+		RequestId:  "",
+		Err:        &notIAMActiveError{role: resp.LoginRole.Role, hint: iamActiveRolesHint(ctx, client)},
+	}
+}
+
+// notIAMActiveError is the error validateIAMEnabled returns when ALKS said the
+// role isn't IAM active, as opposed to failing to answer.
+type notIAMActiveError struct {
+	role string
+	hint string
+}
+
+func (e *notIAMActiveError) Error() string {
+	return fmt.Sprintf("uh oh! You're using the %s role which is not IAM active. %s", e.role, e.hint)
+}
+
+// isNotIAMActive reports whether err says the role isn't IAM active.
+func isNotIAMActive(err *alks.AlksError) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.Err.(*notIAMActiveError)
+
+	return ok
+}
+
+// iamActiveRolesHint suggests the IAM active roles the caller has in ALKS.
+func iamActiveRolesHint(ctx context.Context, client *alks.Client) string {
+	resp, err := traceAlksCall(ctx, client, "GetAccounts", client.GetAccounts)
+	if err != nil {
+		return "Please instead use an IAM active role, such as Admin, IAMAdmin or LabAdmin, or a Machine Identity."
+	}
+
+	var roles []string
+	for _, accountRole := range resp.Accounts {
+		if accountRole.IamActive {
+			roles = append(roles, accountNumber(accountRole.Account)+"/"+accountRole.Role)
+		}
+	}
+	sort.Strings(roles)
+
+	if len(roles) == 0 {
+		return "None of the roles you have in ALKS are IAM active, please use a Machine Identity or ask for access to an IAM active role."
+	}
+
+	return "Please instead use one of the IAM active roles you have in ALKS: " + strings.Join(roles, ", ")
+}
+
+// validateIAMEnabled checks that client, one of the provider's clients, can
+// manage IAM resources.
+func (p *AlksClient) validateIAMEnabled(ctx context.Context, client *alks.Client) *alks.AlksError {
+	callerRoleArn := ""
+	if p.client != nil && client.Credentials == p.client.Credentials {
+		callerRoleArn = p.callerRoleArn
+	}
+
+	return validateIAMEnabled(ctx, client, callerRoleArn)
+}
+
+// callerRoleArn returns the STS ARN of the caller when the caller is an
+// assumed role, which a machine identity always is, or "" otherwise. ALKS's
+// IsIamEnabled takes the STS ARN as is.
+func callerRoleArn(identity *sts.GetCallerIdentityOutput) string {
+	if identity == nil || !strings.Contains(aws.StringValue(identity.Arn), ":assumed-role/") {
+		return ""
+	}
+
+	return aws.StringValue(identity.Arn)
+}
+
+// iamEnabledCheck is the result of checking one of the provider's clients is
+// IAM active, shared by every resource planned with that client.
+type iamEnabledCheck struct {
+	mu   sync.Mutex
+	done bool
+	err  *alks.AlksError
+}
+
+// validateIAMEnabledOnce is validateIAMEnabled, checked until ALKS gives a
+// definite answer for each of the provider's clients. Failures to get an
+// answer aren't kept, so the next resource checks again.
+func (p *AlksClient) validateIAMEnabledOnce(ctx context.Context, client *alks.Client) *alks.AlksError {
+	entry, _ := p.iamEnabledChecks.LoadOrStore(client, &iamEnabledCheck{})
+	check := entry.(*iamEnabledCheck)
+
+	check.mu.Lock()
+	defer check.mu.Unlock()
+
+	if check.done {
+		return check.err
+	}
+
+	err := p.validateIAMEnabled(ctx, client)
+	if err == nil || isNotIAMActive(err) {
+		check.done = true
+		check.err = err
+	}
+
+	return err
+}
+
+// customizeDiffIAMEnabled fails the plan of a change that needs an IAM active
+// role when the provider's role isn't, rather than partway through the apply.
+func customizeDiffIAMEnabled(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	providerStruct := meta.(*AlksClient)
+
+	// read_only stops the apply anyway, and plans of it are expected to have changes
+	if providerStruct.readOnly {
+		return nil
+	}
+	if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	if !d.NewValueKnown("account") || !d.NewValueKnown("role") {
+		return nil
+	}
+
+	client := providerStruct.client
+	account := d.Get("account").(string)
+	role := d.Get("role").(string)
+	if account != "" && role != "" {
+		accountClient, err := providerStruct.accountClients.get(ctx, account, role)
+		if err != nil {
+			return err
 		}
+		client = accountClient
+	}
+
+	if err := providerStruct.validateIAMEnabledOnce(ctx, client); err != nil {
+		return err
 	}
 
 	return nil
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCheckReadOnly(t *testing.T) {
+	if diags := checkReadOnly(&AlksClient{}, "create IAM role"); diags != nil {
+		t.Fatalf("Expected no diagnostics when not read-only, got %#v", diags)
+	}
+
+	diags := checkReadOnly(&AlksClient{readOnly: true}, "create IAM role")
+	if !diags.HasError() {
+		t.Fatal("Expected an error diagnostic when read-only")
+	}
+	if !strings.Contains(diags[0].Summary, "create IAM role") {
+		t.Fatalf("Expected the summary to name the blocked action, got %q", diags[0].Summary)
+	}
+}
+
+func TestReadOnly_BlocksMutations(t *testing.T) {
+	// The client is left nil: any ALKS call made before the read-only check would panic.
+	meta := &AlksClient{readOnly: true, ignoreTags: &IgnoreTags{}}
+
+	cases := []struct {
+		name     string
+		resource *schema.Resource
+		call     func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+	}{
+		{"alks_iamrole create", resourceAlksIamRole(), resourceAlksIamRoleCreate},
+		{"alks_iamrole update", resourceAlksIamRole(), resourceAlksIamRoleUpdate},
+		{"alks_iamrole delete", resourceAlksIamRole(), resourceAlksIamRoleDelete},
+		{"alks_iamtrustrole create", resourceAlksIamTrustRole(), resourceAlksIamTrustRoleCreate},
+		{"alks_ltk create", resourceAlksLtk(), resourceAlksLtkCreate},
+		{"alks_ltk update", resourceAlksLtk(), resourceAlksLtkUpdate},
+		{"alks_ltk delete", resourceAlksLtk(), resourceAlksLtkDelete},
+		{"alks_machine_identity create", resourceAlksMachineIdentity(), resourceAlksMachineIdentityCreate},
+		{"alks_machine_identity delete", resourceAlksMachineIdentity(), resourceAlksMachineIdentityDelete},
+		{"alks_keys read", dataSourceAlksKeys(), dataSourceAlksKeysRead},
+	}
+
+	for _, c := range cases {
+		d := c.resource.TestResourceData()
+		d.SetId("foo")
+
+		diags := c.call(context.Background(), d, meta)
+		if !diags.HasError() {
+			t.Fatalf("%s: expected a read-only error", c.name)
+		}
+	}
+}
+
+func TestValidateIAMEnabled(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	fake.setIamActive(false)
+	fake.addAccount("012345678910/ALKSLabAdmin - foo", alks.AccountRole{Role: "LabAdmin", IamActive: true})
+	fake.addAccount("109876543210/ALKSLabAdmin - bar", alks.AccountRole{Role: "LabAdmin", IamActive: true})
+	fake.addAccount("109876543210/ALKSReadOnly - bar", alks.AccountRole{Role: "ReadOnly", IamActive: false})
+
+	// a machine identity needn't live under ALKS's acct-managed path
+	callerRoleArn := "arn:aws:sts::012345678910:assumed-role/my-mi/i-0123"
+	fake.roles["my-mi"] = &alks.GetIamRoleResponse{RoleName: "my-mi", RoleArn: "arn:aws:iam::012345678910:role/ci/my-mi", Exists: true, AlksAccess: true}
+	fake.setMachineIdentitiesIamActive(false)
+
+	client := fake.client(t)
+
+	expected := "uh oh! You're using the Admin role which is not IAM active. Please instead use one of the IAM active roles you have in ALKS: 012345678910/LabAdmin, 109876543210/LabAdmin"
+
+	if err := validateIAMEnabled(context.Background(), client, ""); err == nil || err.Err.Error() != expected {
+		t.Fatalf("Expected %q, got %v", expected, err)
+	}
+
+	if err := validateIAMEnabled(context.Background(), client, callerRoleArn); err == nil || err.Err.Error() != expected {
+		t.Fatalf("Expected %q for a machine identity that isn't IAM active, got %v", expected, err)
+	}
+
+	fake.setMachineIdentitiesIamActive(true)
+	if err := validateIAMEnabled(context.Background(), client, callerRoleArn); err != nil {
+		t.Fatalf("Expected an IAM active machine identity to pass, got %s", err)
+	}
+	if err := validateIAMEnabled(context.Background(), client, "arn:aws:sts::012345678910:assumed-role/not-an-mi/i-0123"); err == nil || err.Err.Error() != expected {
+		t.Fatalf("Expected %q for a role that isn't a machine identity, got %v", expected, err)
+	}
+
+	meta := &AlksClient{client: client, callerRoleArn: callerRoleArn}
+	if err := meta.validateIAMEnabled(context.Background(), client); err != nil {
+		t.Fatalf("Expected the provider's client to be checked as the machine identity, got %s", err)
+	}
+	other, _ := alks.NewSTSClient(fake.alksURL(), "other", "secret", "token")
+	if err := meta.validateIAMEnabled(context.Background(), other); err == nil {
+		t.Fatalf("Expected an account client not to be checked as the machine identity")
+	}
+}
+
+func TestCallerRoleArn(t *testing.T) {
+	cases := map[string]string{
+		"arn:aws:sts::012345678910:assumed-role/my-mi/i-0123":    "arn:aws:sts::012345678910:assumed-role/my-mi/i-0123",
+		"arn:aws-cn:sts::012345678910:assumed-role/my-mi/i-0123": "arn:aws-cn:sts::012345678910:assumed-role/my-mi/i-0123",
+		"arn:aws:iam::012345678910:user/jdoe":                    "",
+		"arn:aws:sts::012345678910:federated-user/jdoe":          "",
+	}
+
+	for arn, expected := range cases {
+		if got := callerRoleArn(&sts.GetCallerIdentityOutput{Arn: aws.String(arn)}); got != expected {
+			t.Errorf("%s: expected %q, got %q", arn, expected, got)
+		}
+	}
+	if got := callerRoleArn(nil); got != "" {
+		t.Errorf("Expected no caller role without an identity, got %q", got)
+	}
+}
+
+func TestCustomizeDiffIAMEnabled(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	fake.setIamActive(false)
+	client := fake.client(t)
+
+	var loginRoleCalls int32
+	fake.override("GET", "/loginRoles/id/me", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&loginRoleCalls, 1)
+		fake.mux.ServeHTTP(w, r)
+	})
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"iam_username": "my-user"})
+
+	meta := &AlksClient{client: client, ignoreTags: &IgnoreTags{}}
+	for i := 0; i < 2; i++ {
+		_, err := resourceAlksLtk().Diff(context.Background(), nil, config, meta)
+		if err == nil || !strings.Contains(err.Error(), "Admin role which is not IAM active") {
+			t.Fatalf("Expected the plan to fail for a role that isn't IAM active, got %v", err)
+		}
+	}
+	if loginRoleCalls != 1 {
+		t.Fatalf("Expected the role to be checked once per plan, got %d checks", loginRoleCalls)
+	}
+
+	readOnly := &AlksClient{client: client, ignoreTags: &IgnoreTags{}, readOnly: true}
+	if _, err := resourceAlksLtk().Diff(context.Background(), nil, config, readOnly); err != nil {
+		t.Fatalf("Expected read-only plans not to be checked, got %s", err)
+	}
+
+	state := &terraform.InstanceState{ID: "my-user", Attributes: map[string]string{"id": "my-user", "iam_username": "my-user", "tags.%": "0", "tags_all.%": "0"}}
+	unchanged := &AlksClient{client: client, ignoreTags: &IgnoreTags{}}
+	if _, err := resourceAlksLtk().Diff(context.Background(), state, config, unchanged); err != nil {
+		t.Fatalf("Expected plans without changes not to be checked, got %s", err)
+	}
+}
+
+func TestValidateIAMEnabledOnce_RetriesFailures(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	fake.override("GET", "/loginRoles/id/me", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	client := fake.client(t)
+	meta := &AlksClient{client: client}
+
+	if err := meta.validateIAMEnabledOnce(context.Background(), client); err == nil || isNotIAMActive(err) {
+		t.Fatalf("Expected ALKS failing to answer to be an error, got %v", err)
+	}
+
+	fake.override("GET", "/loginRoles/id/me", fake.mux.ServeHTTP)
+	if err := meta.validateIAMEnabledOnce(context.Background(), client); err != nil {
+		t.Fatalf("Expected the check to be retried once ALKS answers, got %s", err)
+	}
+}
@@ -1,14 +1,50 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return Provider()
-		},
-	})
+	ctx := context.Background()
+
+	shutdownTracing, err := initTracing(ctx)
+	if err != nil {
+		log.Printf("[WARN] OpenTelemetry tracing disabled: %s", err)
+	}
+	defer shutdownTracing(ctx)
+
+	if len(os.Args) > 1 {
+		var run func(context.Context, []string, io.Writer, io.Writer) error
+		switch os.Args[1] {
+		case credentialProcessCommand:
+			run = runCredentialProcess
+		case doctorCommand:
+			run = runDoctor
+		}
+
+		if run != nil {
+			if err := run(ctx, os.Args[2:], os.Stdout, os.Stderr); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				shutdownTracing(ctx)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	providerServer, err := newProviderServer(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = tf5server.Serve("registry.terraform.io/Cox-Automotive/alks", providerServer)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
@@ -47,3 +47,11 @@ var ValidRolePrefix = validation.All(
 	validation.StringLenBetween(1, MaxRoleLen-resource.UniqueIDSuffixLength),
 	validation.StringMatch(regexp.MustCompile(`^[\w+=,.@-]+$`), "must match [\\w+=,.@-]"),
 )
+
+// Validate Role ARN based on https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_identifiers.html#identifiers-arns
+var ValidRoleArn = validation.StringMatch(regexp.MustCompile(`^arn:aws[\w-]*:iam::\d{12}:role/[\w+=,.@/-]+$`), "must be an IAM role ARN")
+
+var accountNumberRegexp = regexp.MustCompile(`^\d{12}$`)
+
+// Validate an AWS account number, which ALKS expects to be exactly 12 digits
+var ValidAccountNumber = validation.StringMatch(accountNumberRegexp, "must be a 12 digit AWS account number")
@@ -112,3 +112,41 @@ func TestValidRolePrefix_NamePrefixInvalidChar(t *testing.T) {
 		t.Fatalf("expected 1 validation error")
 	}
 }
+
+func TestValidRoleArn(t *testing.T) {
+	valid := []string{
+		"arn:aws:iam::012345678910:role/my-role",
+		"arn:aws:iam::012345678910:role/acct-managed/my-role",
+		"arn:aws-us-gov:iam::012345678910:role/my-role",
+	}
+	for _, v := range valid {
+		if _, errs := ValidRoleArn(v, "role_arn"); len(errs) != 0 {
+			t.Fatalf("Expected %q to be a valid role ARN, got %v", v, errs)
+		}
+	}
+
+	invalid := []string{
+		"my-role",
+		"arn:aws:iam::012345678910:user/my-user",
+		"arn:aws:iam::0123:role/my-role",
+	}
+	for _, v := range invalid {
+		if _, errs := ValidRoleArn(v, "role_arn"); len(errs) == 0 {
+			t.Fatalf("Expected %q to be an invalid role ARN", v)
+		}
+	}
+}
+
+func TestValidAccountNumber(t *testing.T) {
+	if _, errs := ValidAccountNumber("012345678910", "account"); len(errs) != 0 {
+		t.Fatalf("Expected a 12 digit account number to be valid, got %v", errs)
+	}
+
+	// alks-go slices the first 12 characters of the account, so anything
+	// shorter would panic the provider
+	for _, v := range []string{"123", "01234567891", "0123456789101", "012345678910/ALKSAdmin"} {
+		if _, errs := ValidAccountNumber(v, "account"); len(errs) == 0 {
+			t.Fatalf("Expected %q to be an invalid account number", v)
+		}
+	}
+}
@@ -3,8 +3,11 @@ package main
 import (
 	"context"
 	"log"
+	"sync"
 
 	"github.com/Cox-Automotive/alks-go"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,10 +19,11 @@ func Provider() *schema.Provider {
 	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"url": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "This is the base URL to ALKS service. It must be provided, but it can also be sourced from the ALKS_URL environment variable.",
-				DefaultFunc: schema.EnvDefaultFunc("ALKS_URL", nil),
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "This is the base URL to ALKS service. It must be provided, but it can also be sourced from the ALKS_URL environment variable.",
+				DefaultFunc:  schema.EnvDefaultFunc("ALKS_URL", nil),
+				ValidateFunc: validateAlksURL,
 			},
 			"access_key": {
 				Type:        schema.TypeString,
@@ -74,22 +78,51 @@ func Provider() *schema.Provider {
 				Description: "The role which you'd like to retrieve credentials for.",
 				DefaultFunc: schema.EnvDefaultFunc("Role", nil),
 			},
+			"audit_log_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a local file to append a JSON line to for every create, update or delete call made to ALKS. It can also be sourced from the ALKS_AUDIT_LOG_FILE environment variable.",
+				DefaultFunc: schema.EnvDefaultFunc("ALKS_AUDIT_LOG_FILE", nil),
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, every create, update and delete of a resource, and minting keys with alks_keys, fails before calling ALKS. Useful for drift detection plans.",
+			},
+			"user_agent": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Product tokens (for example \"my-pipeline/1.2.0\") appended to the user agent of every call made to ALKS.",
+			},
 			"assume_role":  assumeRoleSchema(),
 			"default_tags": defaultTagsSchema(),
 			"ignore_tags":  ignoreTagsSchema(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"alks_iamrole":      resourceAlksIamRole(),
-			"alks_iamtrustrole": resourceAlksIamTrustRole(),
-			"alks_ltk":          resourceAlksLtk(),
+			"alks_iamrole":          resourceAlksIamRole(),
+			"alks_iamtrustrole":     resourceAlksIamTrustRole(),
+			"alks_ltk":              resourceAlksLtk(),
+			"alks_machine_identity": resourceAlksMachineIdentity(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"alks_keys": dataSourceAlksKeys(),
+			"alks_keys":            dataSourceAlksKeys(),
+			"alks_iamrole":         dataSourceAlksIamRole(),
+			"alks_accounts":        dataSourceAlksAccounts(),
+			"alks_login_role":      dataSourceAlksLoginRole(),
+			"alks_ltks":            dataSourceAlksLtks(),
+			"alks_ltk":             dataSourceAlksLtk(),
+			"alks_iam_enabled":     dataSourceAlksIamEnabled(),
+			"alks_role_types":      dataSourceAlksRoleTypes(),
+			"alks_caller_identity": dataSourceAlksCallerIdentity(),
 		},
 
-		ConfigureContextFunc: providerConfigure,
+		ProviderMetaSchema: providerMetaSchema(),
+
+		ConfigureContextFunc: configureProvider(""),
 	}
 	return provider
 }
@@ -172,54 +205,98 @@ func ignoreTagsSchema() *schema.Schema {
 	}
 }
 
-func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	var diags diag.Diagnostics
-
-	config := Config{
-		URL:       d.Get("url").(string),
-		AccessKey: d.Get("access_key").(string),
-		SecretKey: d.Get("secret_key").(string),
-		Token:     d.Get("token").(string),
-		Profile:   d.Get("profile").(string),
-		Account:   d.Get("account").(string),
-		Role:      d.Get("role").(string),
-	}
+// configureProvider returns the provider's ConfigureContextFunc. Credentials
+// are verified with the STS endpoint at stsEndpoint, or AWS's when it's "".
+func configureProvider(stsEndpoint string) schema.ConfigureContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		var diags diag.Diagnostics
 
-	assumeRoleList := d.Get("assume_role").(*schema.Set).List()
-	if len(assumeRoleList) == 1 {
-		assumeRole := assumeRoleList[0].(map[string]interface{})
-		config.AssumeRole.RoleARN = assumeRole["role_arn"].(string)
-		config.AssumeRole.SessionName = assumeRole["session_name"].(string)
-		config.AssumeRole.ExternalID = assumeRole["external_id"].(string)
-		config.AssumeRole.Policy = assumeRole["policy"].(string)
-	}
+		url, err := normalizeAlksURL(d.Get("url").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
 
-	// Set CredsFilename, expanding home directory
-	credsPath, err := homedir.Expand(d.Get("shared_credentials_file").(string))
-	if err != nil {
-		return nil, diag.FromErr(err)
-	}
-	config.CredsFilename = credsPath
-	defaultTags := expandProviderDefaultTags(d.Get("default_tags").([]interface{}))
-	ignoreTags := expandProviderIgnoreTags(d.Get("ignore_tags").([]interface{}))
+		config := Config{
+			URL:         url,
+			AccessKey:   d.Get("access_key").(string),
+			SecretKey:   d.Get("secret_key").(string),
+			Token:       d.Get("token").(string),
+			Profile:     d.Get("profile").(string),
+			Account:     d.Get("account").(string),
+			Role:        d.Get("role").(string),
+			STSEndpoint: stsEndpoint,
+		}
 
-	c, err := config.Client()
-	if err != nil {
-		return nil, diag.FromErr(err)
-	}
+		for _, product := range d.Get("user_agent").([]interface{}) {
+			if product != nil {
+				config.UserAgent = append(config.UserAgent, product.(string))
+			}
+		}
 
-	alksClient := &AlksClient{}
-	alksClient.client = c
-	if defaultTags != nil {
-		alksClient.defaultTags = defaultTags
-	}
+		assumeRoleList := d.Get("assume_role").(*schema.Set).List()
+		if len(assumeRoleList) == 1 {
+			assumeRole := assumeRoleList[0].(map[string]interface{})
+			config.AssumeRole.RoleARN = assumeRole["role_arn"].(string)
+			config.AssumeRole.SessionName = assumeRole["session_name"].(string)
+			config.AssumeRole.ExternalID = assumeRole["external_id"].(string)
+			config.AssumeRole.Policy = assumeRole["policy"].(string)
+		}
 
-	if ignoreTags != nil {
-		alksClient.ignoreTags = ignoreTags
-	}
+		// Set CredsFilename, expanding home directory
+		credsPath, err := homedir.Expand(d.Get("shared_credentials_file").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		config.CredsFilename = credsPath
+		defaultTags := expandProviderDefaultTags(d.Get("default_tags").([]interface{}))
+		ignoreTags := expandProviderIgnoreTags(d.Get("ignore_tags").([]interface{}))
+
+		c, err := config.Client(ctx)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		alksClient := &AlksClient{}
+		alksClient.client = c
+		alksClient.userAgent = config.userAgent()
+		alksClient.readOnly = d.Get("read_only").(bool)
+		alksClient.callerIdentity = config.callerIdentity
+		alksClient.credentialSource = config.credentialSource
+		alksClient.assumeRoleArn = config.AssumeRole.RoleARN
+		if c == config.baseClient {
+			alksClient.callerRoleArn = callerRoleArn(config.baseIdentity)
+		}
+		alksClient.accountClients = newClientPool(func(ctx context.Context, account string, role string) (*alks.Client, *alks.AlksError) {
+			client, err := newAccountClient(ctx, config.URL, account, role, config.baseClient)
+			if err != nil {
+				return nil, err
+			}
+			accountClient := *client
+			accountClient.SetUserAgent(alksClient.userAgent)
 
-	log.Println("[INFO] Initializing ALKS client")
-	return alksClient, diags
+			return &accountClient, nil
+		})
+		alksClient.sessions = newSessionCache()
+		if defaultTags != nil {
+			alksClient.defaultTags = defaultTags
+		}
+
+		if ignoreTags != nil {
+			alksClient.ignoreTags = ignoreTags
+		}
+
+		auditLogPath, err := homedir.Expand(d.Get("audit_log_file").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		alksClient.auditLog, err = newAuditLogger(auditLogPath, aws.StringValue(config.baseIdentity.Arn))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		log.Println("[INFO] Initializing ALKS client")
+		return alksClient, diags
+	}
 }
 
 func expandProviderDefaultTags(l []interface{}) TagMap {
@@ -265,7 +342,17 @@ func expandProviderIgnoreTags(l []interface{}) *IgnoreTags {
 }
 
 type AlksClient struct {
-	client      *alks.Client
-	defaultTags TagMap //Not making this a pointer because I was having to check everywhere if it was nil
-	ignoreTags  *IgnoreTags
+	client           *alks.Client
+	accountClients   *clientPool
+	sessions         *sessionCache
+	defaultTags      TagMap //Not making this a pointer because I was having to check everywhere if it was nil
+	ignoreTags       *IgnoreTags
+	auditLog         *auditLogger
+	readOnly         bool
+	userAgent        string
+	callerIdentity   *sts.GetCallerIdentityOutput
+	credentialSource string
+	assumeRoleArn    string
+	callerRoleArn    string
+	iamEnabledChecks sync.Map
 }
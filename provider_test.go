@@ -2,8 +2,10 @@ package main
 
 import (
 	"os"
+	"os/exec"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -17,6 +19,35 @@ func init() {
 	}
 }
 
+// TestMain runs the acceptance tests against an in-process fake ALKS when
+// ALKS_URL isn't set, so they can run without ALKS or AWS credentials. They
+// still need the Terraform CLI, so without TF_ACC they only run when it's on
+// the PATH.
+func TestMain(m *testing.M) {
+	if os.Getenv("ALKS_URL") != "" {
+		os.Exit(m.Run())
+	}
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			os.Exit(m.Run())
+		}
+		os.Setenv(resource.EnvTfAcc, "1")
+	}
+
+	fake := newFakeAlks()
+	testAccProvider.ConfigureContextFunc = configureProvider(fake.URL)
+	os.Setenv("ALKS_URL", fake.alksURL())
+	for _, prefix := range []string{"ALKS_", "AWS_"} {
+		os.Setenv(prefix+"ACCESS_KEY_ID", fakeAlksAccessKey)
+		os.Setenv(prefix+"SECRET_ACCESS_KEY", "secret")
+		os.Setenv(prefix+"SESSION_TOKEN", "token")
+	}
+
+	code := m.Run()
+	fake.Close()
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("Error: %s", err)
@@ -20,7 +20,7 @@ func resourceAlksIamRole() *schema.Resource {
 		UpdateContext: resourceAlksIamRoleUpdate,
 		DeleteContext: resourceAlksIamRoleDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateAccountQualified,
 		},
 		SchemaVersion: 1,
 		MigrateState:  migrateState,
@@ -90,18 +90,25 @@ func resourceAlksIamRole() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"account":  AccountSchema(),
+			"role":     RoleSchema(),
 			"tags":     TagsSchema(),
 			"tags_all": TagsSchemaComputed(),
 		},
 		CustomizeDiff: customdiff.All(
 			SetTagsDiff,
 			trustPoliciesWithIncludeDefaultPolicies,
+			customizeDiffIAMEnabled,
 		),
 	}
 }
 
 func resourceAlksIamRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] ALKS IAM Role Create")
+	if diags := checkReadOnly(meta, "create IAM role"); diags != nil {
+		return diags
+	}
+
 	var roleName = NameWithPrefix(d.Get("name").(string), d.Get("name_prefix").(string))
 	var incDefPol = d.Get("include_default_policies").(bool)
 	var enableAlksAccess = d.Get("enable_alks_access").(bool)
@@ -120,12 +127,15 @@ func resourceAlksIamRoleCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	//Role Specific tags will overwrite default tags if value is defined in both maps
 	allTags := tagMapToSlice(combineTagMaps(providerStruct.defaultTags, tags))
 
-	if err := validateIAMEnabled(client); err != nil {
+	if err := providerStruct.validateIAMEnabled(ctx, client); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -151,7 +161,10 @@ func resourceAlksIamRoleCreate(ctx context.Context, d *schema.ResourceData, meta
 		options.TrustPolicy = trustPolicy
 	}
 
-	resp, err := client.CreateIamRole(options)
+	resp, err := traceAlksCall(ctx, client, "CreateIamRole", func() (*alks.IamRoleResponse, *alks.AlksError) {
+		return client.CreateIamRole(options)
+	})
+	providerStruct.auditLog.record(client, "CreateIamRole", roleName, resp, err)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -166,14 +179,24 @@ func resourceAlksIamRoleCreate(ctx context.Context, d *schema.ResourceData, meta
 
 func resourceAlksIamRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] ALKS IAM Role Delete")
+	if diags := checkReadOnly(meta, "delete IAM role"); diags != nil {
+		return diags
+	}
 
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
-	if err := validateIAMEnabled(client); err != nil {
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := providerStruct.validateIAMEnabled(ctx, client); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := client.DeleteIamRole(d.Id()); err != nil {
+	err = traceAlksErr(ctx, client, "DeleteIamRole", func() *alks.AlksError {
+		return client.DeleteIamRole(d.Id())
+	})
+	providerStruct.auditLog.record(client, "DeleteIamRole", d.Id(), nil, err)
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -183,7 +206,10 @@ func resourceAlksIamRoleDelete(ctx context.Context, d *schema.ResourceData, meta
 func resourceAlksIamRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] ALKS IAM Role Read")
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	defaultTags := providerStruct.defaultTags
 	ignoreTags := providerStruct.ignoreTags
@@ -193,7 +219,9 @@ func resourceAlksIamRoleRead(ctx context.Context, d *schema.ResourceData, meta i
 		return nil
 	}
 
-	foundRole, err := client.GetIamRole(d.Id())
+	foundRole, err := traceAlksCall(ctx, client, "GetIamRole", func() (*alks.GetIamRoleResponse, *alks.AlksError) {
+		return client.GetIamRole(d.Id())
+	})
 
 	if err != nil {
 		//If error is 404, RoleNotFound, we log it and let terraform decide how to handle it.
@@ -241,11 +269,17 @@ func resourceAlksIamRoleRead(ctx context.Context, d *schema.ResourceData, meta i
 
 func resourceAlksIamRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] ALKS IAM Role Update")
+	if diags := checkReadOnly(meta, "update IAM role"); diags != nil {
+		return diags
+	}
 
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	if err := validateIAMEnabled(client); err != nil {
+	if err := providerStruct.validateIAMEnabled(ctx, client); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -254,14 +288,16 @@ func resourceAlksIamRoleUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	if d.HasChange("enable_alks_access") {
 		// try updating enable_alks_access
-		if err := updateAlksAccess(d, meta); err != nil {
+		if err := updateAlksAccess(ctx, d, meta); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
 	//Do a read to get existing tags.  If any of those are in ignore_tags, then they are externally managed
 	//and they should be included in the update so they don't get removed.
-	foundRole, err := client.GetIamRole(d.Id())
+	foundRole, err := traceAlksCall(ctx, client, "GetIamRole", func() (*alks.GetIamRoleResponse, *alks.AlksError) {
+		return client.GetIamRole(d.Id())
+	})
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -295,8 +331,12 @@ func resourceAlksIamRoleUpdate(ctx context.Context, d *schema.ResourceData, meta
 		options.TrustPolicy = trustPolicy
 	}
 
-	if _, err := client.UpdateIamRole(&options); err != nil {
-		return diag.FromErr(err)
+	resp, updateErr := traceAlksCall(ctx, client, "UpdateIamRole", func() (*alks.UpdateIamRoleResponse, *alks.AlksError) {
+		return client.UpdateIamRole(&options)
+	})
+	providerStruct.auditLog.record(client, "UpdateIamRole", foundRole.RoleName, resp, updateErr)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
 	}
 
 	d.Partial(false)
@@ -304,23 +344,32 @@ func resourceAlksIamRoleUpdate(ctx context.Context, d *schema.ResourceData, meta
 	return resourceAlksIamRoleRead(ctx, d, meta)
 }
 
-func updateAlksAccess(d *schema.ResourceData, meta interface{}) error {
+func updateAlksAccess(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	var alksAccess = d.Get("enable_alks_access").(bool)
 	var roleArn = d.Get("arn").(string)
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
-	if err := validateIAMEnabled(client); err != nil {
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return err
+	}
+	if err := providerStruct.validateIAMEnabled(ctx, client); err != nil {
 		return err
 	}
 	// create the machine identity
 	if alksAccess {
-		_, err := client.AddRoleMachineIdentity(roleArn)
+		resp, err := traceAlksCall(ctx, client, "AddRoleMachineIdentity", func() (*alks.MachineIdentityResponse, *alks.AlksError) {
+			return client.AddRoleMachineIdentity(roleArn)
+		})
+		providerStruct.auditLog.record(client, "AddRoleMachineIdentity", roleArn, resp, err)
 		if err != nil {
 			return err
 		}
 	} else {
 		// delete the machine identity
-		_, err := client.DeleteRoleMachineIdentity(roleArn)
+		resp, err := traceAlksCall(ctx, client, "DeleteRoleMachineIdentity", func() (*alks.MachineIdentityResponse, *alks.AlksError) {
+			return client.DeleteRoleMachineIdentity(roleArn)
+		})
+		providerStruct.auditLog.record(client, "DeleteRoleMachineIdentity", roleArn, resp, err)
 		if err != nil {
 			return err
 		}
@@ -328,17 +377,22 @@ func updateAlksAccess(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func updateIamRoleTags(d *schema.ResourceData, meta interface{}) error {
+func updateIamRoleTags(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return err
+	}
 
-	if err := validateIAMEnabled(client); err != nil {
+	if err := providerStruct.validateIAMEnabled(ctx, client); err != nil {
 		return err
 	}
 
 	//Do a read to get existing tags.  If any of those are in ignore_tags, then they are externally managed
 	//and they should be included in the update so they don't get removed.
-	foundRole, err := client.GetIamRole(d.Id())
+	foundRole, err := traceAlksCall(ctx, client, "GetIamRole", func() (*alks.GetIamRoleResponse, *alks.AlksError) {
+		return client.GetIamRole(d.Id())
+	})
 
 	if err != nil {
 		return err
@@ -356,8 +410,12 @@ func updateIamRoleTags(d *schema.ResourceData, meta interface{}) error {
 		Tags:     &tags,
 	}
 
-	if _, err := client.UpdateIamRole(&options); err != nil {
-		return err
+	resp, updateErr := traceAlksCall(ctx, client, "UpdateIamRole", func() (*alks.UpdateIamRoleResponse, *alks.AlksError) {
+		return client.UpdateIamRole(&options)
+	})
+	providerStruct.auditLog.record(client, "UpdateIamRole", foundRole.RoleName, resp, updateErr)
+	if updateErr != nil {
+		return updateErr
 	}
 	return nil
 }
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"regexp"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/Cox-Automotive/alks-go"
@@ -1043,3 +1047,94 @@ const testAccCheckAlksIamRoleTrustPolicyAndDefaultPoliciesTrue = `
 		})
 	}
 `
+
+func TestResourceAlksIamRole_Lifecycle(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	meta := fake.configure(t, map[string]interface{}{
+		"default_tags": []interface{}{map[string]interface{}{
+			"tags": map[string]interface{}{"defaultTagKey1": "defaultTagValue1"},
+		}},
+	})
+	r := resourceAlksIamRole()
+
+	state := fakeApply(t, r, meta, nil, map[string]interface{}{
+		"name":                     "bar430",
+		"type":                     "Amazon EC2",
+		"include_default_policies": false,
+		"tags":                     map[string]interface{}{"testKey1": "testValue1"},
+	})
+	if state.ID != "bar430" || state.Attributes["ip_arn"] == "" {
+		t.Fatalf("Expected role bar430 with an instance profile, got %v", state)
+	}
+	if state.Attributes["tags_all.defaultTagKey1"] != "defaultTagValue1" {
+		t.Fatalf("Expected the default tags in tags_all, got %v", state.Attributes)
+	}
+
+	state = fakeApply(t, r, meta, state, map[string]interface{}{
+		"name":                     "bar430",
+		"type":                     "Amazon EC2",
+		"include_default_policies": false,
+		"tags":                     map[string]interface{}{"testKey2": "testValue2"},
+	})
+	if tags := tagSliceToMap(fake.roles["bar430"].Tags); len(tags) != 2 || tags["testKey2"] != "testValue2" {
+		t.Fatalf("Expected the role's tags to be updated, got %v", tags)
+	}
+
+	fakeDestroy(t, r, meta, state)
+	if _, err := meta.client.GetIamRole("bar430"); err == nil || err.StatusCode != 404 {
+		t.Fatalf("Expected the role to be deleted, got %v", err)
+	}
+}
+
+func TestResourceAlksIamRole_LifecycleTrustPolicy(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	meta := fake.configure(t, nil)
+	r := resourceAlksIamRole()
+	policy := func(service string) string {
+		return fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Action":"sts:AssumeRole","Effect":"Allow","Principal":{"Service":"%s"},"Sid":""}]}`, service)
+	}
+
+	state := fakeApply(t, r, meta, nil, map[string]interface{}{
+		"name":                     "bar430",
+		"assume_role_policy":       policy("databrew.amazonaws.com"),
+		"include_default_policies": false,
+	})
+	state = fakeApply(t, r, meta, state, map[string]interface{}{
+		"name":                     "bar430",
+		"assume_role_policy":       policy("ec2.amazonaws.com"),
+		"include_default_policies": false,
+	})
+	if principals := trustPrincipals(fake.roles["bar430"].TrustPolicy); len(principals) != 1 || principals[0] != "ec2.amazonaws.com" {
+		t.Fatalf("Expected the trust policy to be updated, got %v", principals)
+	}
+
+	fakeDestroy(t, r, meta, state)
+}
+
+func TestResourceAlksIamRole_NotIamActive(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	fake.setIamActive(false)
+	var accountsCalls int32
+	fake.override("POST", "/getAccounts/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&accountsCalls, 1)
+		fake.mux.ServeHTTP(w, r)
+	})
+
+	// the role is only checked by plans that need it, not every configure
+	meta, diags := fake.configureDiags(t, nil)
+	if len(diags) != 0 || atomic.LoadInt32(&accountsCalls) != 0 {
+		t.Fatalf("Expected configuring not to check the role, got %v and %d GetAccounts calls", diags, accountsCalls)
+	}
+
+	_, err := resourceAlksIamRole().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                     "bar430",
+		"type":                     "Amazon EC2",
+		"include_default_policies": false,
+	}), meta)
+	if err == nil || !strings.Contains(err.Error(), "not IAM active") {
+		t.Fatalf("Expected the plan to fail for a role that isn't IAM active, got %v", err)
+	}
+	if len(fake.roles) != 0 {
+		t.Fatalf("Expected no role to be created")
+	}
+}
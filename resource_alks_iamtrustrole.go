@@ -8,6 +8,7 @@ import (
 
 	"github.com/Cox-Automotive/alks-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -19,7 +20,7 @@ func resourceAlksIamTrustRole() *schema.Resource {
 		UpdateContext: resourceAlksIamRoleUpdate,
 		DeleteContext: resourceAlksIamRoleDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateAccountQualified,
 		},
 		SchemaVersion: 1,
 		MigrateState:  migrateState,
@@ -73,15 +74,23 @@ func resourceAlksIamTrustRole() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"account":  AccountSchema(),
+			"role":     RoleSchema(),
 			"tags":     TagsSchema(),
 			"tags_all": TagsSchemaComputed(),
 		},
-		CustomizeDiff: SetTagsDiff,
+		CustomizeDiff: customdiff.All(
+			SetTagsDiff,
+			customizeDiffIAMEnabled,
+		),
 	}
 }
 
 func resourceAlksIamTrustRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] ALKS IAM Trust Role Create")
+	if diags := checkReadOnly(meta, "create IAM trust role"); diags != nil {
+		return diags
+	}
 
 	var roleName = NameWithPrefix(d.Get("name").(string), d.Get("name_prefix").(string))
 	var roleType = d.Get("type").(string)
@@ -91,17 +100,22 @@ func resourceAlksIamTrustRoleCreate(ctx context.Context, d *schema.ResourceData,
 	var max_session_duration_in_seconds = d.Get("max_session_duration_in_seconds").(int)
 
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
+	client, clientErr := providerStruct.resourceClient(ctx, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
-	if err := validateIAMEnabled(client); err != nil {
+	if err := providerStruct.validateIAMEnabled(ctx, client); err != nil {
 		return diag.FromErr(err)
 	}
 
 	allTags := tagMapToSlice(combineTagMaps(providerStruct.defaultTags, tags))
 
 	var resp *alks.IamRoleResponse
+	attempt := 0
 	err := resource.RetryContext(ctx, 2*time.Minute, func() *resource.RetryError {
 		var err *alks.AlksError
+		attempt++
 
 		options := &alks.CreateIamRoleOptions{
 			RoleName:                    &roleName,
@@ -111,7 +125,10 @@ func resourceAlksIamTrustRoleCreate(ctx context.Context, d *schema.ResourceData,
 			Tags:                        &allTags,
 			MaxSessionDurationInSeconds: &max_session_duration_in_seconds,
 		}
-		resp, err = client.CreateIamTrustRole(options)
+		resp, err = traceAlksCall(ctx, client, "CreateIamTrustRole", func() (*alks.IamRoleResponse, *alks.AlksError) {
+			return client.CreateIamTrustRole(options)
+		}, attrAlksRetryAttempt.Int(attempt))
+		providerStruct.auditLog.record(client, "CreateIamTrustRole", roleName, resp, err)
 		if err != nil {
 			if strings.Contains(err.Error(), "Role already exists") || strings.Contains(err.Error(), "Instance profile exists") {
 				return resource.NonRetryableError(err)
@@ -481,3 +481,38 @@ const testAccCheckAlksIamRoleUpdateTrustWithTagsWithIgnoredTags = `
 		}
 	}
 `
+
+func TestResourceAlksIamTrustRole_Lifecycle(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	meta := fake.configure(t, nil)
+	roleResource := resourceAlksIamRole()
+	r := resourceAlksIamTrustRole()
+
+	role := fakeApply(t, roleResource, meta, nil, map[string]interface{}{
+		"name":                     "foo",
+		"type":                     "Amazon EC2",
+		"include_default_policies": false,
+	})
+	config := map[string]interface{}{
+		"name":      "bar",
+		"type":      "Inner Account",
+		"trust_arn": role.Attributes["arn"],
+		"tags":      map[string]interface{}{"testKey1": "testValue1"},
+	}
+	state := fakeApply(t, r, meta, nil, config)
+	if state.ID != "bar" || state.Attributes["arn"] == "" {
+		t.Fatalf("Expected trust role bar, got %v", state)
+	}
+
+	config["tags"] = map[string]interface{}{"testKey2": "testValue2"}
+	state = fakeApply(t, r, meta, state, config)
+	if tags := fake.roles["bar"].Tags; len(tags) != 1 || tags[0].Key != "testKey2" {
+		t.Fatalf("Expected the trust role's tags to be updated, got %v", tags)
+	}
+
+	fakeDestroy(t, r, meta, state)
+	fakeDestroy(t, roleResource, meta, role)
+	if len(fake.roles) != 0 {
+		t.Fatalf("Expected both roles to be deleted, got %v", fake.roles)
+	}
+}
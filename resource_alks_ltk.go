@@ -17,7 +17,7 @@ func resourceAlksLtk() *schema.Resource {
 		UpdateContext: resourceAlksLtkUpdate,
 		DeleteContext: resourceAlksLtkDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateAccountQualified,
 		},
 		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
@@ -40,23 +40,32 @@ func resourceAlksLtk() *schema.Resource {
 				Type:      schema.TypeString,
 				Computed:  true,
 			},
+			"account":  AccountSchema(),
+			"role":     RoleSchema(),
 			"tags":     TagsSchema(),
 			"tags_all": TagsSchemaComputed(),
 		},
 		CustomizeDiff: customdiff.All(
 			SetTagsDiff,
+			customizeDiffIAMEnabled,
 		),
 	}
 }
 
 func resourceAlksLtkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] ALKS LTK User Create")
+	if diags := checkReadOnly(meta, "create LTK user"); diags != nil {
+		return diags
+	}
 
 	var iamUsername = d.Get("iam_username").(string)
 	var tags = d.Get("tags").(map[string]interface{})
 
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	allTags := tagMapToSlice(combineTagMaps(providerStruct.defaultTags, tags))
 
@@ -64,11 +73,14 @@ func resourceAlksLtkCreate(ctx context.Context, d *schema.ResourceData, meta int
 		IamUserName: &iamUsername,
 		Tags:        &allTags,
 	}
-	if err := validateIAMEnabled(client); err != nil {
+	if err := providerStruct.validateIAMEnabled(ctx, client); err != nil {
 		return diag.FromErr(err)
 	}
 
-	resp, err := client.CreateIamUser(options)
+	resp, err := traceAlksCall(ctx, client, "CreateIamUser", func() (*alks.CreateIamUserResponse, *alks.AlksError) {
+		return client.CreateIamUser(options)
+	})
+	providerStruct.auditLog.record(client, "CreateIamUser", iamUsername, resp, err)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -87,7 +99,10 @@ func resourceAlksLtkRead(ctx context.Context, d *schema.ResourceData, meta inter
 	log.Printf("[INFO] ALKS LTK User Read")
 
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	defaultTags := providerStruct.defaultTags
 	ignoreTags := providerStruct.ignoreTags
@@ -97,7 +112,9 @@ func resourceAlksLtkRead(ctx context.Context, d *schema.ResourceData, meta inter
 		return nil
 	}
 
-	resp, err := client.GetIamUser(d.Id())
+	resp, err := traceAlksCall(ctx, client, "GetIamUser", func() (*alks.GetIamUserResponse, *alks.AlksError) {
+		return client.GetIamUser(d.Id())
+	})
 
 	if err != nil {
 		//If error is 404, UserNotFound, we log it and let terraform decide how to handle it.
@@ -133,13 +150,16 @@ func resourceAlksLtkRead(ctx context.Context, d *schema.ResourceData, meta inter
 
 func resourceAlksLtkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] ALKS LTK Update")
+	if diags := checkReadOnly(meta, "update LTK user"); diags != nil {
+		return diags
+	}
 
 	// enable partial state mode
 	d.Partial(true)
 
 	if d.HasChange("tags_all") {
 		// try updating enable_alks_access
-		if err := updateUserTags(d, meta); err != nil {
+		if err := updateUserTags(ctx, d, meta); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -151,31 +171,46 @@ func resourceAlksLtkUpdate(ctx context.Context, d *schema.ResourceData, meta int
 
 func resourceAlksLtkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] ALKS LTK User Delete")
+	if diags := checkReadOnly(meta, "delete LTK user"); diags != nil {
+		return diags
+	}
 
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
-	if err := validateIAMEnabled(client); err != nil {
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := providerStruct.validateIAMEnabled(ctx, client); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if _, err := client.DeleteIamUser(d.Id()); err != nil {
+	resp, err := traceAlksCall(ctx, client, "DeleteIamUser", func() (*alks.DeleteIamUserResponse, *alks.AlksError) {
+		return client.DeleteIamUser(d.Id())
+	})
+	providerStruct.auditLog.record(client, "DeleteIamUser", d.Id(), resp, err)
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func updateUserTags(d *schema.ResourceData, meta interface{}) error {
+func updateUserTags(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	providerStruct := meta.(*AlksClient)
-	client := providerStruct.client
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return err
+	}
 
-	if err := validateIAMEnabled(client); err != nil {
+	if err := providerStruct.validateIAMEnabled(ctx, client); err != nil {
 		return err
 	}
 
 	//Do a read to get existing tags.  If any of those are in ignore_tags, then they are externally managed
 	//and they should be included in the update so they don't get removed.
-	resp, err := client.GetIamUser(d.Id())
+	resp, err := traceAlksCall(ctx, client, "GetIamUser", func() (*alks.GetIamUserResponse, *alks.AlksError) {
+		return client.GetIamUser(d.Id())
+	})
 
 	if err != nil {
 		return err
@@ -193,8 +228,12 @@ func updateUserTags(d *schema.ResourceData, meta interface{}) error {
 		Tags:        &tags,
 	}
 
-	if _, err := client.UpdateIamUser(&options); err != nil {
-		return err
+	updateResp, updateErr := traceAlksCall(ctx, client, "UpdateIamUser", func() (*alks.UpdateIamUserResponse, *alks.AlksError) {
+		return client.UpdateIamUser(&options)
+	})
+	providerStruct.auditLog.record(client, "UpdateIamUser", resp.User.UserName, updateResp, updateErr)
+	if updateErr != nil {
+		return updateErr
 	}
 	return nil
 }
@@ -277,3 +277,36 @@ const testAccCheckAlksLtkUpdateWithTagsWithIgnoredTags = `
 		  }
 	  }
 `
+
+func TestResourceAlksLtk_Lifecycle(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	meta := fake.configure(t, map[string]interface{}{
+		"ignore_tags": []interface{}{map[string]interface{}{
+			"keys": []interface{}{"ignoreFullKey"},
+		}},
+	})
+	r := resourceAlksLtk()
+
+	state := fakeApply(t, r, meta, nil, map[string]interface{}{
+		"iam_username": "TEST_LTK_USER",
+		"tags":         map[string]interface{}{"cloud": "railway"},
+	})
+	if state.ID != "TEST_LTK_USER" || state.Attributes["access_key"] == "" || state.Attributes["secret_key"] == "" {
+		t.Fatalf("Expected LTK user TEST_LTK_USER with keys, got %v", state.Attributes)
+	}
+
+	// Tags set outside of Terraform and ignored must survive an update
+	fake.users["TEST_LTK_USER"].Tags = append(fake.users["TEST_LTK_USER"].Tags, alks.Tag{Key: "ignoreFullKey", Value: "external"})
+	state = fakeApply(t, r, meta, state, map[string]interface{}{
+		"iam_username": "TEST_LTK_USER",
+		"tags":         map[string]interface{}{"cloud2": "railway2"},
+	})
+	if tags := tagSliceToMap(fake.users["TEST_LTK_USER"].Tags); len(tags) != 2 || tags["cloud2"] != "railway2" || tags["ignoreFullKey"] != "external" {
+		t.Fatalf("Expected the LTK user's tags to be updated, got %v", tags)
+	}
+
+	fakeDestroy(t, r, meta, state)
+	if resp, _ := meta.client.GetIamUser("TEST_LTK_USER"); resp != nil {
+		t.Fatalf("Expected the LTK user to be deleted, got %v", resp)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceAlksMachineIdentity() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAlksMachineIdentityCreate,
+		ReadContext:   resourceAlksMachineIdentityRead,
+		DeleteContext: resourceAlksMachineIdentityDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidRoleArn,
+			},
+			"machine_identity_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAlksMachineIdentityCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ALKS Machine Identity Create")
+	if diags := checkReadOnly(meta, "create machine identity"); diags != nil {
+		return diags
+	}
+
+	var roleArn = d.Get("role_arn").(string)
+
+	providerStruct := meta.(*AlksClient)
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := providerStruct.validateIAMEnabled(ctx, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := traceAlksCall(ctx, client, "AddRoleMachineIdentity", func() (*alks.MachineIdentityResponse, *alks.AlksError) {
+		return client.AddRoleMachineIdentity(roleArn)
+	})
+	providerStruct.auditLog.record(client, "AddRoleMachineIdentity", roleArn, resp, err)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(roleArn)
+
+	log.Printf("[INFO] alks_machine_identity.id: %v", d.Id())
+
+	return resourceAlksMachineIdentityRead(ctx, d, meta)
+}
+
+func resourceAlksMachineIdentityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ALKS Machine Identity Read")
+
+	providerStruct := meta.(*AlksClient)
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := traceAlksCall(ctx, client, "SearchRoleMachineIdentity", func() (*alks.MachineIdentityResponse, *alks.AlksError) {
+		return client.SearchRoleMachineIdentity(d.Id())
+	})
+
+	if err != nil {
+		//If error is 404, the role isn't a machine identity, we log it and let terraform decide how to handle it.
+		//All other errors cause a failure
+		if err.StatusCode == 404 {
+			log.Printf("[Error] %s", err)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if resp.MachineIdentityArn == "" {
+		log.Printf("[WARN] Role %s is no longer a machine identity", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("role_arn", d.Id())
+	_ = d.Set("machine_identity_arn", resp.MachineIdentityArn)
+
+	return nil
+}
+
+func resourceAlksMachineIdentityDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ALKS Machine Identity Delete")
+	if diags := checkReadOnly(meta, "delete machine identity"); diags != nil {
+		return diags
+	}
+
+	providerStruct := meta.(*AlksClient)
+	client, err := providerStruct.resourceClient(ctx, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := providerStruct.validateIAMEnabled(ctx, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := traceAlksCall(ctx, client, "DeleteRoleMachineIdentity", func() (*alks.MachineIdentityResponse, *alks.AlksError) {
+		return client.DeleteRoleMachineIdentity(d.Id())
+	})
+	providerStruct.auditLog.record(client, "DeleteRoleMachineIdentity", d.Id(), resp, err)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
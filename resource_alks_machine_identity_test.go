@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAlksMachineIdentity_Basic(t *testing.T) {
+	var resp alks.IamRoleResponse
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(testAccCheckAlksMachineIdentityDestroy, testAccCheckAlksIamRoleDestroy(&resp)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckAlksMachineIdentityConfigBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"alks_machine_identity.foo", "role_arn", "alks_iamrole.foo", "arn"),
+					resource.TestCheckResourceAttrSet(
+						"alks_machine_identity.foo", "machine_identity_arn"),
+				),
+			},
+			{
+				ResourceName:      "alks_machine_identity.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAlksMachineIdentityDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*AlksClient).client
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alks_machine_identity" {
+			continue
+		}
+
+		resp, err := client.SearchRoleMachineIdentity(rs.Primary.ID)
+		if err == nil && resp.MachineIdentityArn != "" {
+			return fmt.Errorf("Machine identity still exists: %#v", resp)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckAlksMachineIdentityConfigBasic = `
+	resource "alks_iamrole" "foo" {
+		name = "foo"
+		type = "Amazon EC2"
+		include_default_policies = false
+	}
+	resource "alks_machine_identity" "foo" {
+		role_arn = alks_iamrole.foo.arn
+	}
+`
+
+func TestResourceAlksMachineIdentity_Lifecycle(t *testing.T) {
+	fake := newTestFakeAlks(t)
+	meta := fake.configure(t, nil)
+	roleResource := resourceAlksIamRole()
+	r := resourceAlksMachineIdentity()
+
+	role := fakeApply(t, roleResource, meta, nil, map[string]interface{}{
+		"name":                     "foo",
+		"type":                     "Amazon EC2",
+		"include_default_policies": false,
+	})
+	state := fakeApply(t, r, meta, nil, map[string]interface{}{
+		"role_arn": role.Attributes["arn"],
+	})
+	if state.Attributes["machine_identity_arn"] != role.Attributes["arn"] {
+		t.Fatalf("Expected a machine identity for %s, got %v", role.Attributes["arn"], state.Attributes)
+	}
+
+	fakeDestroy(t, r, meta, state)
+	if _, err := meta.client.SearchRoleMachineIdentity(role.Attributes["arn"]); err == nil || err.StatusCode != 404 {
+		t.Fatalf("Expected the machine identity to be deleted, got %v", err)
+	}
+	fakeDestroy(t, roleResource, meta, role)
+}
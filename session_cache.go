@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+// sessionRefreshWindow is how close to expiry a cached session is minted again.
+const sessionRefreshWindow = 5 * time.Minute
+
+// sessionCache shares the sessions alks_keys mints, so every consumer in a run
+// asking for the same keys gets one session. Concurrent requests for the same
+// keys wait on a single mint; a failed mint is retried on the next request.
+type sessionCache struct {
+	mint func(ctx context.Context, client *alks.Client, req keysRequest) (*alks.SessionResponse, *alks.Client, error)
+
+	mu       sync.Mutex
+	sessions map[sessionKey]*cachedSession
+}
+
+// sessionKey identifies a session by the account minting it and what was asked for.
+type sessionKey struct {
+	account string
+	keysRequest
+}
+
+type cachedSession struct {
+	once    sync.Once
+	session *alks.SessionResponse
+	client  *alks.Client
+	err     error
+}
+
+func newSessionCache() *sessionCache {
+	return &sessionCache{
+		mint:     mintKeys,
+		sessions: make(map[sessionKey]*cachedSession),
+	}
+}
+
+// get returns a session for req minted with client, reusing one from earlier
+// in the run until it nears expiry. A nil cache mints every time.
+func (c *sessionCache) get(ctx context.Context, client *alks.Client, req keysRequest) (*alks.SessionResponse, *alks.Client, error) {
+	if c == nil {
+		return mintKeys(ctx, client, req)
+	}
+
+	key := sessionKey{account: client.AccountDetails.Account, keysRequest: req}
+
+	c.mu.Lock()
+	entry, ok := c.sessions[key]
+	if !ok || entry.expiring() {
+		entry = &cachedSession{}
+		c.sessions[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		session, sessionClient, err := c.mint(ctx, client, req)
+
+		c.mu.Lock()
+		entry.session, entry.client, entry.err = session, sessionClient, err
+		c.mu.Unlock()
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry.err != nil {
+		if c.sessions[key] == entry {
+			delete(c.sessions, key)
+		}
+
+		return nil, nil, entry.err
+	}
+
+	return entry.session, entry.client, nil
+}
+
+// expiring reports whether the session is close enough to expiry to be
+// replaced. It must be called with the cache's lock held. Sessions still
+// being minted are never expiring, so callers wait for them instead.
+func (s *cachedSession) expiring() bool {
+	if s.session == nil || s.session.Expires.IsZero() {
+		return false
+	}
+
+	return time.Until(s.session.Expires) < sessionRefreshWindow
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+func TestSessionCache_SharesSessions(t *testing.T) {
+	var mints int32
+	cache := newSessionCache()
+	cache.mint = func(ctx context.Context, client *alks.Client, req keysRequest) (*alks.SessionResponse, *alks.Client, error) {
+		atomic.AddInt32(&mints, 1)
+		time.Sleep(10 * time.Millisecond)
+		return &alks.SessionResponse{AccessKey: req.account, Expires: time.Now().Add(time.Hour)}, client, nil
+	}
+	client := &alks.Client{AccountDetails: alks.AccountDetails{Account: "012345678910/ALKSAdmin"}}
+	req := keysRequest{account: "109876543210", role: "LabAdmin", durationHours: 1, useIAM: true}
+
+	var wg sync.WaitGroup
+	sessions := make([]*alks.SessionResponse, 10)
+	for i := range sessions {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sessions[i], _, _ = cache.get(context.Background(), client, req)
+		}(i)
+	}
+	wg.Wait()
+
+	if mints != 1 {
+		t.Fatalf("Expected one mint, got %d", mints)
+	}
+	for _, s := range sessions {
+		if s != sessions[0] {
+			t.Fatalf("Expected every caller to share one session")
+		}
+	}
+
+	longer := req
+	longer.durationHours = 2
+	if _, _, err := cache.get(context.Background(), client, longer); err != nil || mints != 2 {
+		t.Fatalf("Expected a different duration to mint a new session, got %d mints and %v", mints, err)
+	}
+}
+
+func TestSessionCache_RefreshesExpiringSessions(t *testing.T) {
+	var mints int32
+	cache := newSessionCache()
+	cache.mint = func(ctx context.Context, client *alks.Client, req keysRequest) (*alks.SessionResponse, *alks.Client, error) {
+		atomic.AddInt32(&mints, 1)
+		return &alks.SessionResponse{Expires: time.Now().Add(sessionRefreshWindow / 2)}, client, nil
+	}
+	client := &alks.Client{}
+
+	first, _, _ := cache.get(context.Background(), client, keysRequest{durationHours: 1})
+	second, _, _ := cache.get(context.Background(), client, keysRequest{durationHours: 1})
+
+	if mints != 2 || first == second {
+		t.Fatalf("Expected a session near expiry to be minted again, got %d mints", mints)
+	}
+}
+
+func TestSessionCache_RetriesFailedMints(t *testing.T) {
+	var mints int32
+	cache := newSessionCache()
+	cache.mint = func(ctx context.Context, client *alks.Client, req keysRequest) (*alks.SessionResponse, *alks.Client, error) {
+		if atomic.AddInt32(&mints, 1) == 1 {
+			return nil, nil, errors.New("boom")
+		}
+		return &alks.SessionResponse{Expires: time.Now().Add(time.Hour)}, client, nil
+	}
+	client := &alks.Client{}
+
+	if _, _, err := cache.get(context.Background(), client, keysRequest{}); err == nil {
+		t.Fatalf("Expected the first mint to fail")
+	}
+	if _, _, err := cache.get(context.Background(), client, keysRequest{}); err != nil {
+		t.Fatalf("Expected the mint to be retried, got %s", err)
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Cox-Automotive/terraform-provider-alks"
+
+// Span attribute keys recorded on ALKS and STS calls
+const (
+	attrAlksOperation    = attribute.Key("alks.operation")
+	attrAlksAccount      = attribute.Key("alks.account")
+	attrAlksRole         = attribute.Key("alks.role")
+	attrAlksStatusCode   = attribute.Key("alks.status_code")
+	attrAlksRequestID    = attribute.Key("alks.request_id")
+	attrAlksRetryAttempt = attribute.Key("alks.retry.attempt")
+	attrAwsService       = attribute.Key("aws.service")
+	attrAwsOperation     = attribute.Key("aws.operation")
+	attrAwsStatusCode    = attribute.Key("aws.status_code")
+	attrAwsRequestID     = attribute.Key("aws.request_id")
+	attrAwsRetryCount    = attribute.Key("aws.retry_count")
+)
+
+// tracer is looked up from the global provider on every use so spans follow
+// whichever provider is currently installed.
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// tracingEnabled reports whether OTLP trace export has been requested through
+// the standard OTEL_* environment variables. Tracing is opt-in; nothing is
+// exported unless an OTLP endpoint or the otlp traces exporter is configured.
+func tracingEnabled() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+
+	if os.Getenv("OTEL_TRACES_EXPORTER") != "" {
+		return os.Getenv("OTEL_TRACES_EXPORTER") == "otlp"
+	}
+
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// initTracing installs a global tracer provider exporting spans over OTLP when
+// tracing is enabled. The returned function flushes and stops the exporter and
+// is always safe to call.
+func initTracing(ctx context.Context) (func(context.Context), error) {
+	noop := func(context.Context) {}
+
+	if !tracingEnabled() {
+		return noop, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, fmt.Errorf("Error creating OTLP trace exporter: %s", err)
+	}
+
+	res, err := resource.Merge(
+		resource.NewSchemaless(
+			attribute.String("service.name", "terraform-provider-alks"),
+			attribute.String("service.version", getPluginVersion()),
+		),
+		resource.Environment(),
+	)
+	if err != nil {
+		return noop, fmt.Errorf("Error creating trace resource: %s", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	log.Println("[INFO] OpenTelemetry tracing enabled")
+
+	return func(ctx context.Context) {
+		if err := tp.Shutdown(ctx); err != nil {
+			log.Printf("[WARN] Error shutting down tracer provider: %s", err)
+		}
+	}, nil
+}
+
+// traceAlksCall wraps a single ALKS endpoint call in a span. The request ID of
+// the response is recorded when ALKS returns one, as are the status code and
+// request ID of any *alks.AlksError.
+func traceAlksCall[T any](ctx context.Context, client *alks.Client, operation string, call func() (T, *alks.AlksError), attrs ...attribute.KeyValue) (T, *alks.AlksError) {
+	span := startAlksSpan(ctx, client, operation, attrs...)
+	defer span.End()
+
+	resp, err := call()
+	if reqID := responseRequestID(resp); reqID != "" {
+		span.SetAttributes(attrAlksRequestID.String(reqID))
+	}
+	endAlksSpan(span, err)
+
+	return resp, err
+}
+
+// traceAlksErr is traceAlksCall for ALKS client methods that only return an error.
+func traceAlksErr(ctx context.Context, client *alks.Client, operation string, call func() *alks.AlksError, attrs ...attribute.KeyValue) *alks.AlksError {
+	span := startAlksSpan(ctx, client, operation, attrs...)
+	defer span.End()
+
+	err := call()
+	endAlksSpan(span, err)
+
+	return err
+}
+
+func startAlksSpan(ctx context.Context, client *alks.Client, operation string, attrs ...attribute.KeyValue) trace.Span {
+	_, span := tracer().Start(ctx, "ALKS "+operation, trace.WithSpanKind(trace.SpanKindClient))
+	span.SetAttributes(attrAlksOperation.String(operation))
+	if client != nil {
+		span.SetAttributes(
+			attrAlksAccount.String(client.AccountDetails.Account),
+			attrAlksRole.String(client.AccountDetails.Role),
+		)
+	}
+	span.SetAttributes(attrs...)
+
+	return span
+}
+
+func endAlksSpan(span trace.Span, err *alks.AlksError) {
+	if err == nil {
+		span.SetStatus(codes.Ok, "")
+		return
+	}
+
+	if err.StatusCode != 0 {
+		span.SetAttributes(attrAlksStatusCode.Int(err.StatusCode))
+	}
+	if err.RequestId != "" {
+		span.SetAttributes(attrAlksRequestID.String(err.RequestId))
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// responseRequestID returns the request ID from the BaseResponse embedded in
+// most ALKS response types, or "" if the response doesn't carry one.
+func responseRequestID(resp interface{}) string {
+	v := reflect.ValueOf(resp)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	f := v.FieldByName("BaseResponse")
+	if !f.IsValid() {
+		return ""
+	}
+
+	if base, ok := f.Interface().(alks.BaseResponse); ok {
+		return base.RequestID
+	}
+
+	return ""
+}
+
+// traceAWSRequests adds handlers emitting a span for every attempt of every
+// AWS API call made through clients created from the given handlers.
+func traceAWSRequests(ctx context.Context, handlers *request.Handlers) {
+	handlers.Send.PushFrontNamed(request.NamedHandler{
+		Name: "alks.tracing.StartSpan",
+		Fn: func(r *request.Request) {
+			_, span := tracer().Start(ctx, r.ClientInfo.ServiceID+" "+r.Operation.Name, trace.WithSpanKind(trace.SpanKindClient))
+			span.SetAttributes(
+				attrAwsService.String(r.ClientInfo.ServiceID),
+				attrAwsOperation.String(r.Operation.Name),
+				attrAwsRetryCount.Int(r.RetryCount),
+			)
+			r.SetContext(trace.ContextWithSpan(r.Context(), span))
+		},
+	})
+
+	handlers.CompleteAttempt.PushBackNamed(request.NamedHandler{
+		Name: "alks.tracing.EndSpan",
+		Fn: func(r *request.Request) {
+			span := trace.SpanFromContext(r.Context())
+			if !span.IsRecording() {
+				return
+			}
+
+			if r.HTTPResponse != nil {
+				span.SetAttributes(attrAwsStatusCode.Int(r.HTTPResponse.StatusCode))
+			}
+			if r.RequestID != "" {
+				span.SetAttributes(attrAwsRequestID.String(r.RequestID))
+			}
+			if r.Error != nil {
+				span.RecordError(r.Error)
+				span.SetStatus(codes.Error, r.Error.Error())
+			} else {
+				span.SetStatus(codes.Ok, "")
+			}
+			span.End()
+		},
+	})
+}
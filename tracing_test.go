@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupTestTracing(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+		otel.SetTracerProvider(previous)
+	})
+
+	return exporter
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTraceAlksCall_Success(t *testing.T) {
+	exporter := setupTestTracing(t)
+	fake := newTestFakeAlks(t)
+	fake.override("PATCH", "/role/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"requestId": "req-200", "roleName": "foo"}`)
+	})
+	client := fake.client(t)
+
+	roleName := "foo"
+	_, alksErr := traceAlksCall(context.Background(), client, "UpdateIamRole", func() (*alks.UpdateIamRoleResponse, *alks.AlksError) {
+		return client.UpdateIamRole(&alks.UpdateIamRoleRequest{RoleName: &roleName})
+	}, attrAlksRetryAttempt.Int(2))
+	if alksErr != nil {
+		t.Fatalf("Unexpected error: %s", alksErr)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name != "ALKS UpdateIamRole" {
+		t.Fatalf("Unexpected span name %q", span.Name)
+	}
+	if span.Status.Code != codes.Ok {
+		t.Fatalf("Expected span status Ok, got %v", span.Status.Code)
+	}
+
+	attrs := spanAttributes(span)
+	if v := attrs[attrAlksRequestID].AsString(); v != "req-200" {
+		t.Fatalf("Expected request ID req-200, got %q", v)
+	}
+	if v := attrs[attrAlksAccount].AsString(); v != fakeAlksAccount+"/ALKS"+fakeAlksRole {
+		t.Fatalf("Unexpected account %q", v)
+	}
+	if v := attrs[attrAlksRetryAttempt].AsInt64(); v != 2 {
+		t.Fatalf("Expected retry attempt 2, got %d", v)
+	}
+}
+
+func TestTraceAlksCall_Error(t *testing.T) {
+	exporter := setupTestTracing(t)
+	fake := newTestFakeAlks(t)
+	client := fake.client(t)
+
+	_, alksErr := traceAlksCall(context.Background(), client, "GetIamRole", func() (*alks.GetIamRoleResponse, *alks.AlksError) {
+		return client.GetIamRole("foo")
+	})
+	if alksErr == nil {
+		t.Fatal("Expected an error")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Status.Code != codes.Error {
+		t.Fatalf("Expected span status Error, got %v", span.Status.Code)
+	}
+
+	attrs := spanAttributes(span)
+	if v := attrs[attrAlksStatusCode].AsInt64(); v != http.StatusNotFound {
+		t.Fatalf("Expected status code 404, got %d", v)
+	}
+	if v := attrs[attrAlksRequestID].AsString(); !strings.HasPrefix(v, "fake-") {
+		t.Fatalf("Expected the fake's request ID, got %q", v)
+	}
+}
+
+func TestTraceAWSRequests(t *testing.T) {
+	exporter := setupTestTracing(t)
+	fake := newTestFakeAlks(t)
+
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(fake.URL),
+		Credentials: credentials.NewStaticCredentials("access", "secret", "token"),
+	})
+	if err != nil {
+		t.Fatalf("Error creating session: %s", err)
+	}
+	traceAWSRequests(context.Background(), &sess.Handlers)
+
+	if _, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name != "STS GetCallerIdentity" {
+		t.Fatalf("Unexpected span name %q", span.Name)
+	}
+
+	attrs := spanAttributes(span)
+	if v := attrs[attrAwsStatusCode].AsInt64(); v != http.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", v)
+	}
+	if v := attrs[attrAwsRequestID].AsString(); v != "fake-sts" {
+		t.Fatalf("Expected request ID fake-sts, got %q", v)
+	}
+	if v := attrs[attrAwsRetryCount].AsInt64(); v != 0 {
+		t.Fatalf("Expected retry count 0, got %d", v)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Cox-Automotive/alks-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerMeta is the provider_meta block a module can declare to identify
+// itself in the user agent of calls made to ALKS for its resources.
+type providerMeta struct {
+	ModuleName    *string `cty:"module_name"`
+	ModuleVersion *string `cty:"module_version"`
+}
+
+func providerMetaSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"module_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Name of the module using the provider, appended to the user agent sent to ALKS.",
+		},
+		"module_version": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Version of the module using the provider, appended to the user agent sent to ALKS.",
+		},
+	}
+}
+
+// appendUserAgent adds each non-empty product to the end of a user agent.
+func appendUserAgent(userAgent string, products ...string) string {
+	parts := []string{userAgent}
+	for _, p := range products {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// moduleProduct formats provider_meta as a user agent product, or returns ""
+// when no module name was given.
+func (m providerMeta) moduleProduct() string {
+	if m.ModuleName == nil || *m.ModuleName == "" {
+		return ""
+	}
+
+	if m.ModuleVersion == nil || *m.ModuleVersion == "" {
+		return *m.ModuleName
+	}
+
+	return fmt.Sprintf("%s/%s", *m.ModuleName, *m.ModuleVersion)
+}
+
+// resourceClient returns the ALKS client to use for calls made on behalf of d.
+// Resources that set account and role get a client for that account from the
+// provider's pool; everything else shares the provider's client.
+func (p *AlksClient) resourceClient(ctx context.Context, d *schema.ResourceData) (*alks.Client, *alks.AlksError) {
+	base := p.client
+
+	account, _ := d.Get("account").(string)
+	role, _ := d.Get("role").(string)
+	if account != "" && role != "" {
+		accountClient, err := p.accountClients.get(ctx, account, role)
+		if err != nil {
+			return nil, err
+		}
+		base = accountClient
+	}
+
+	return p.attributedClient(d, base), nil
+}
+
+// attributedClient returns base, or a copy of it with the module appended to
+// its user agent when the resource's module sets provider_meta. Attribution is
+// best effort, so an unreadable provider_meta falls back to base.
+func (p *AlksClient) attributedClient(d *schema.ResourceData, base *alks.Client) *alks.Client {
+	var meta providerMeta
+	if err := d.GetProviderMeta(&meta); err != nil {
+		log.Printf("[WARN] Error reading provider_meta: %s", err)
+		return base
+	}
+
+	product := meta.moduleProduct()
+	if product == "" {
+		return base
+	}
+
+	client := *base
+	client.SetUserAgent(appendUserAgent(p.userAgent, product))
+
+	return &client
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Cox-Automotive/alks-go"
+)
+
+func TestAppendUserAgent(t *testing.T) {
+	cases := []struct {
+		userAgent string
+		products  []string
+		expected  string
+	}{
+		{
+			userAgent: "alks-terraform-provider-1.0.0",
+			products:  nil,
+			expected:  "alks-terraform-provider-1.0.0",
+		},
+		{
+			userAgent: "alks-terraform-provider-1.0.0",
+			products:  []string{"my-pipeline/2.0", " ", "vpc-roles/1.2.0"},
+			expected:  "alks-terraform-provider-1.0.0 my-pipeline/2.0 vpc-roles/1.2.0",
+		},
+	}
+
+	for _, c := range cases {
+		if actual := appendUserAgent(c.userAgent, c.products...); actual != c.expected {
+			t.Fatalf("Expected user agent %q, got %q", c.expected, actual)
+		}
+	}
+}
+
+func TestProviderMeta_ModuleProduct(t *testing.T) {
+	name := "vpc-roles"
+	version := "1.2.0"
+	empty := ""
+
+	cases := []struct {
+		meta     providerMeta
+		expected string
+	}{
+		{providerMeta{}, ""},
+		{providerMeta{ModuleName: &empty, ModuleVersion: &version}, ""},
+		{providerMeta{ModuleName: &name}, "vpc-roles"},
+		{providerMeta{ModuleName: &name, ModuleVersion: &version}, "vpc-roles/1.2.0"},
+	}
+
+	for _, c := range cases {
+		if actual := c.meta.moduleProduct(); actual != c.expected {
+			t.Fatalf("Expected module product %q, got %q", c.expected, actual)
+		}
+	}
+}
+
+func TestResourceClient_NoProviderMeta(t *testing.T) {
+	client := &alks.Client{}
+	providerStruct := &AlksClient{client: client, userAgent: "alks-terraform-provider-1.0.0"}
+
+	d := resourceAlksIamRole().TestResourceData()
+	if actual, err := providerStruct.resourceClient(context.Background(), d); err != nil || actual != client {
+		t.Fatal("Expected the shared client when provider_meta isn't set")
+	}
+}